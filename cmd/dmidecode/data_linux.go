@@ -5,40 +5,44 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"io"
-	"os"
-	"path/filepath"
+
+	"github.com/u-root/smbios"
 )
 
-// getData returns SMBIOS entry point and DMI table data.
-// If dumpFile is non-empty, it is read from that file, otherwise it is read
-// from sysfsPath (smbios_entry_point and DMI files respectively).
-func getData(textOut io.Writer, dumpFile, sysfsPath string) ([]byte, []byte, error) {
-	var err error
-	var entry, data []byte
-	if dumpFile != "" {
-		fmt.Fprintf(textOut, "Reading SMBIOS/DMI data from file %s.\n", dumpFile)
-		data, err = os.ReadFile(dumpFile)
-		if err != nil {
-			return nil, nil, fmt.Errorf("error reading dump: %v", err)
-		}
-		if len(data) < 36 {
-			return nil, nil, errors.New("dump is too short")
-		}
-		entry = data[:32]
-		data = data[32:]
-	} else {
-		fmt.Fprintf(textOut, "Reading SMBIOS/DMI data from sysfs.\n")
-		entry, err = os.ReadFile(filepath.Join(sysfsPath, "smbios_entry_point"))
-		if err != nil {
-			return nil, nil, fmt.Errorf("error reading DMI data: %v", err)
-		}
-		data, err = os.ReadFile(filepath.Join(sysfsPath, "DMI"))
-		if err != nil {
-			return nil, nil, fmt.Errorf("error reading DMI data: %v", err)
+// efiSystabPath is the EFI System Table used with --no-sysfs, the default if empty.
+var efiSystabPath = ""
+
+// getSources returns the sources to read SMBIOS data from, in order.
+// If dumpFile is non-empty, it is read from that file, and if imageFile is
+// non-empty, from that firmware image. Otherwise, if memPath
+// is non-empty, it is read from that image of physical memory (/dev/mem),
+// at the address given by the EFI System Table or found in the F0000 segment.
+// Otherwise it is read from sysfsPath.
+func getSources(dumpFile, imageFile, sysfsPath, memPath string) []smbios.Source {
+	switch {
+	case dumpFile != "":
+		return []smbios.Source{smbios.DumpFileSource{Path: dumpFile}}
+	case imageFile != "":
+		return []smbios.Source{smbios.ImageSource{Path: imageFile}}
+	case memPath != "":
+		return []smbios.Source{
+			smbios.EFISource{SystabPath: efiSystabPath, MemPath: memPath},
+			smbios.MemoryImageSource{Path: memPath},
 		}
+	default:
+		return []smbios.Source{smbios.SysfsSource{Path: sysfsPath}}
+	}
+}
+
+// getData returns SMBIOS entry point and DMI table data from the first of
+// the sources returned by getSources that can be read.
+func getData(textOut io.Writer, dumpFile, imageFile, sysfsPath, memPath string) ([]byte, []byte, error) {
+	entry, data, src, err := smbios.ReadFirst(getSources(dumpFile, imageFile, sysfsPath, memPath)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading DMI data: %v", err)
 	}
+	fmt.Fprintf(textOut, "Reading SMBIOS/DMI data from %s.\n", src)
 	return entry, data, nil
 }
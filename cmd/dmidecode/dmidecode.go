@@ -14,9 +14,11 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -38,14 +40,28 @@ func (s *stringList) String() string {
 }
 
 var (
+	flagDump     = flag.Bool("dump", false, `Do not decode the entries, dump their contents as hexadecimal instead. Note that this is still a text output, no binary data will be thrown upon you. The strings attached to each entry are displayed as both hexadecimal and ASCII. This option is mainly useful for debugging.`)
 	flagDumpBin  = flag.String("dump-bin", "", `Do not decode the entries, instead dump the DMI data to a file in binary form. The generated file is suitable to pass to --from-dump later.`)
 	flagFromDump = flag.String("from-dump", "", `Read the DMI data from a binary file previously generated using --dump-bin.`)
+	flagFromImg  = flag.String("from-image", "", `Read the DMI data from a raw firmware image, such as a .rom or .fd file. The image is searched for an entry point and the structure table it refers to.`)
+	flagFormat   = flag.String("format", formatText, `Output format, either "text" (dmidecode(8) compatible) or "json". JSON output contains the entry point and every table, decoded where supported as by dmidecode.MarshalTableJSON and raw as by dmidecode.MarshalRawTableJSON otherwise.`)
+	flagNoSysfs  = flag.Bool("no-sysfs", false, `Do not attempt to read DMI data from sysfs files. Instead, read the entry point from the EFI system table or by scanning the F0000 segment, and the DMI table from /dev/mem.`)
 	flagType     []string
+	flagString   string
+	flagHandle   string
+	flagQuiet    bool
 )
 
 func init() {
 	flag.Var((*stringList)(&flagType), "type", `Only  display  the  entries of type TYPE. TYPE can be either a DMI type number, or a comma-separated list of type numbers, or a keyword from the following list: bios, system, baseboard, chassis, processor, memory, cache, connector, slot. If this option is used more than once, the set of displayed entries will be the union of all the given types. If TYPE is not provided or not valid, a list of all valid keywords is printed and dmidecode exits with an error.`)
 	flag.Var((*stringList)(&flagType), "t", "Alias for --type")
+	flag.StringVar(&flagString, "string", "", `Only display the value of the DMI string identified by KEYWORD. KEYWORD must be a keyword from the following list: bios-vendor, bios-version, bios-release-date, bios-revision, firmware-revision, system-manufacturer, system-product-name, system-version, system-serial-number, system-uuid, system-sku-number, system-family, baseboard-manufacturer, baseboard-product-name, baseboard-version, baseboard-serial-number, baseboard-asset-tag, chassis-manufacturer, chassis-type, chassis-version, chassis-serial-number, chassis-asset-tag, processor-family, processor-manufacturer, processor-version, processor-frequency. Each keyword corresponds to a given DMI type and a given offset within this entry type. Not all strings may be meaningful or even defined on all systems. Some keywords may return more than one result on some systems (e.g. processor-version on a multi-processor system). If KEYWORD is not provided or not valid, a list of all valid keywords is printed and dmidecode exits with an error. This option cannot be used more than once.`)
+	flag.StringVar(&flagString, "s", "", "Alias for --string")
+	flag.BoolVar(flagDump, "u", false, "Alias for --dump")
+	flag.StringVar(&flagHandle, "handle", "", `Only display the entry whose handle matches HANDLE. HANDLE is a 16-bit integer.`)
+	flag.StringVar(&flagHandle, "H", "", "Alias for --handle")
+	flag.BoolVar(&flagQuiet, "quiet", false, `Be less verbose. Entries of unknown or unsupported type, inactive entries and the end-of-table entry are not displayed. Meta-data and handle lines are hidden.`)
+	flag.BoolVar(&flagQuiet, "q", false, "Alias for --quiet")
 }
 
 var typeGroups = map[string][]uint8{
@@ -85,17 +101,38 @@ func parseTypeFilter(typeStrings []string) (map[smbios.TableType]bool, error) {
 }
 
 func dumpBin(textOut io.Writer, entryData, tableData []byte, fileName string) *dmiDecodeError {
-	// Need to rewrite address to be compatible with dmidecode(8).
 	entry, err := smbios.ParseEntry(bytes.NewReader(entryData))
 	if err != nil {
 		return &dmiDecodeError{code: 1, error: fmt.Errorf("error parsing entry point structure: %v", err)}
 	}
+	tables, err := smbios.ParseTables(bytes.NewReader(tableData))
+	if err != nil {
+		return &dmiDecodeError{code: 1, error: fmt.Errorf("error parsing tables: %v", err)}
+	}
+	if tableData, err = tables.MarshalBinary(); err != nil {
+		return &dmiDecodeError{code: 1, error: fmt.Errorf("error encoding tables: %v", err)}
+	}
 
+	// Need to rewrite address to be compatible with dmidecode(8). The other
+	// fields must match the data that is written, the table read from memory
+	// (--no-sysfs) may be shorter than the entry point says.
+	const tableAddr = 0x20
+	major, minor, rev := entry.Version()
 	switch e := entry.(type) {
-	case *smbios.Entry32:
-		e.StructTableAddr = 0x20
 	case *smbios.Entry64:
-		e.StructTableAddr = 0x20
+		entry, err = smbios.NewEntry64(uint8(major), uint8(minor), uint8(rev), tableAddr, tables)
+	case *smbios.EntryDMI:
+		if len(tableData) > math.MaxUint16 || len(tables) > math.MaxUint16 {
+			return &dmiDecodeError{code: 1, error: fmt.Errorf("table too large for a legacy DMI entry point: %d bytes", len(tableData))}
+		}
+		e.StructTableAddr = tableAddr
+		e.StructTableLength = uint16(len(tableData))
+		e.NumberOfStructs = uint16(len(tables))
+	default:
+		entry, err = smbios.NewEntry32(uint8(major), uint8(minor), tableAddr, tables)
+	}
+	if err != nil {
+		return &dmiDecodeError{code: 1, error: err}
 	}
 
 	edata, err := entry.MarshalBinary()
@@ -103,7 +140,7 @@ func dumpBin(textOut io.Writer, entryData, tableData []byte, fileName string) *d
 		return &dmiDecodeError{code: 1, error: err}
 	}
 
-	f, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0o644)
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
 	if err != nil {
 		return &dmiDecodeError{code: 1, error: fmt.Errorf("error opening file for writing: %v", err)}
 	}
@@ -126,13 +163,100 @@ func dumpBin(textOut io.Writer, entryData, tableData []byte, fileName string) *d
 	return nil
 }
 
+// hexRows formats data as rows of up to 16 hexadecimal bytes.
+func hexRows(data []byte) []string {
+	var rows []string
+	for len(data) > 0 {
+		n := min(len(data), 16)
+		row := make([]string, n)
+		for i, d := range data[:n] {
+			row[i] = fmt.Sprintf("%02X", d)
+		}
+		rows = append(rows, strings.Join(row, " "))
+		data = data[n:]
+	}
+	return rows
+}
+
+// dumpTable formats the raw contents of t like dmidecode(8) --dump. It is
+// similar to t.String, but strings are shown as hexadecimal as well.
+func dumpTable(t *smbios.Table) string {
+	lines := []string{"\tHeader and Data:"}
+	for _, r := range hexRows(append(t.Header.ToBytes(), t.Data...)) {
+		lines = append(lines, "\t\t"+r)
+	}
+	if len(t.Strings) > 0 {
+		lines = append(lines, "\tStrings:")
+		for _, s := range t.Strings {
+			for _, r := range hexRows(append([]byte(s), 0)) {
+				lines = append(lines, "\t\t"+r)
+			}
+			lines = append(lines, "\t\t"+strings.Map(func(r rune) rune {
+				if r < ' ' || r > '~' {
+					return '.'
+				}
+				return r
+			}, s))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func dmiDecode(textOut io.Writer) *dmiDecodeError {
 	typeFilter, err := parseTypeFilter(flagType)
 	if err != nil {
 		return &dmiDecodeError{code: 2, error: fmt.Errorf("invalid --type: %v", err)}
 	}
+	if *flagFormat != formatText && *flagFormat != formatJSON {
+		return &dmiDecodeError{code: 2, error: fmt.Errorf("invalid --format: %q, must be %q or %q", *flagFormat, formatText, formatJSON)}
+	}
+	exclusive := 0
+	for _, set := range []bool{flagString != "", len(flagType) != 0, flagHandle != "", *flagDumpBin != ""} {
+		if set {
+			exclusive++
+		}
+	}
+	if exclusive > 1 {
+		return &dmiDecodeError{code: 2, error: errors.New("options --string, --type, --handle and --dump-bin are mutually exclusive")}
+	}
+	if *flagFromDump != "" && *flagFromImg != "" {
+		return &dmiDecodeError{code: 2, error: errors.New("options --from-dump and --from-image are mutually exclusive")}
+	}
+	handle := -1
+	if flagHandle != "" {
+		h, err := strconv.ParseUint(flagHandle, 0, 16)
+		if err != nil {
+			return &dmiDecodeError{code: 2, error: fmt.Errorf("invalid --handle: %s", flagHandle)}
+		}
+		handle = int(h)
+	}
+	if *flagDump && *flagFormat != formatText {
+		return &dmiDecodeError{code: 2, error: errors.New("option --dump can only be used with --format text")}
+	}
+	var kw *stringKeyword
+	if flagString != "" {
+		if *flagFormat != formatText {
+			return &dmiDecodeError{code: 2, error: errors.New("option --string can only be used with --format text")}
+		}
+		if kw = findStringKeyword(flagString); kw == nil {
+			return &dmiDecodeError{code: 2, error: errors.New(stringKeywordsUsage(flagString))}
+		}
+	}
+	out := textOut
+	if (*flagFormat == formatJSON && *flagDumpBin == "") || kw != nil || flagQuiet {
+		// Informational messages would make the output invalid JSON, and
+		// only the value is printed for --string.
+		textOut = io.Discard
+	}
+	include := func(t *smbios.Table) bool {
+		return (len(typeFilter) == 0 || typeFilter[t.Type]) && (handle < 0 || int(t.Handle) == handle)
+	}
+	memPath := ""
+	if *flagNoSysfs {
+		memPath = "/dev/mem"
+	}
 	fmt.Fprintf(textOut, "# dmidecode-go\n") // TODO: version.
-	entryData, tableData, err := getData(textOut, *flagFromDump, "/sys/firmware/dmi/tables")
+	entryData, tableData, err := getData(textOut, *flagFromDump, *flagFromImg, smbios.DefaultSysfsPath, memPath)
 	if err != nil {
 		return &dmiDecodeError{code: 1, error: fmt.Errorf("error parsing loading data: %v", err)}
 	}
@@ -143,24 +267,54 @@ func dmiDecode(textOut io.Writer) *dmiDecodeError {
 	if err != nil {
 		return &dmiDecodeError{code: 1, error: fmt.Errorf("error parsing data: %v", err)}
 	}
+	if kw != nil {
+		printStringKeyword(out, si, kw)
+		return nil
+	}
+	if *flagFormat == formatJSON {
+		if err := writeJSON(out, si, include); err != nil {
+			return &dmiDecodeError{code: 1, error: fmt.Errorf("error writing JSON: %v", err)}
+		}
+		return nil
+	}
 	fmt.Fprintf(textOut, "%s present.\n", si.Entry)
-	if e32, ok := si.Entry.(*smbios.Entry32); ok {
-		fmt.Fprintf(textOut, "%d structures occupying %d bytes.\n", e32.NumberOfStructs, e32.StructTableLength)
+	switch e := si.Entry.(type) {
+	case *smbios.Entry32:
+		fmt.Fprintf(textOut, "%d structures occupying %d bytes.\n", e.NumberOfStructs, e.StructTableLength)
+	case *smbios.EntryDMI:
+		fmt.Fprintf(textOut, "%d structures occupying %d bytes.\n", e.NumberOfStructs, e.StructTableLength)
 	}
 	fmt.Fprintf(textOut, "\n")
 	for _, t := range si.Tables {
-		if len(typeFilter) != 0 && !typeFilter[t.Type] {
+		if flagQuiet && t.Type == smbios.TableTypeEndOfTable {
+			break
+		}
+		if !include(t) || (flagQuiet && t.Type == smbios.TableTypeInactive) {
+			continue
+		}
+		if *flagDump {
+			if !flagQuiet {
+				fmt.Fprintf(out, "Handle 0x%04X, DMI type %d, %d bytes\n", t.Handle, uint8(t.Type), t.Length)
+			}
+			fmt.Fprintf(out, "%s\n\n", dumpTable(t))
 			continue
 		}
 		pt, err := dmidecode.ParseTypedTable(t)
 		if err != nil {
 			if err != dmidecode.ErrUnsupportedTableType {
 				fmt.Fprintf(os.Stderr, "%s\n", err)
+			} else if flagQuiet {
+				continue
 			}
 			// Print as raw table
 			pt = t
 		}
-		fmt.Fprintf(textOut, "%s\n\n", pt)
+		s := pt.String()
+		if flagQuiet {
+			// Replace the handle line with just the type name.
+			s = t.Type.String() + strings.TrimPrefix(s, t.Header.String())
+		}
+		fmt.Fprintf(out, "%s\n\n", s)
 	}
 	return nil
 }
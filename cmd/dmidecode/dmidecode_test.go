@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,6 +16,8 @@ import (
 	"testing"
 
 	"github.com/u-root/gobusybox/src/pkg/golang"
+	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/dmidecode"
 )
 
 func testOutput(t *testing.T, dmidecode, gocoverdir, dumpFile string, args []string, expectedOutFile string) {
@@ -46,6 +49,49 @@ func testOutput(t *testing.T, dmidecode, gocoverdir, dumpFile string, args []str
 	})
 }
 
+// testDumpBinRoundTrip checks that --dump-bin of a dump file, which was
+// written by dmidecode(8), reproduces its tables and entry point version.
+func testDumpBinRoundTrip(t *testing.T, dmidecode, gocoverdir, dumpFile string) {
+	t.Helper()
+
+	t.Run("", func(t *testing.T) {
+		outFile := filepath.Join(t.TempDir(), "dump.bin")
+		c := exec.Command(dmidecode, "--from-dump", dumpFile, "--dump-bin", outFile)
+		c.Env = append(os.Environ(), "GOCOVERDIR="+gocoverdir)
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Logf("out: %s", out)
+			t.Fatalf("run: %v", err)
+		}
+		want, err := os.ReadFile(dumpFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The entry point is rebuilt from the tables, firmware does not
+		// always fill in the maximum structure size and revisions exactly.
+		if len(got) < 0x20 || !bytes.Equal(got[0x20:], want[0x20:]) {
+			t.Fatalf("%s: --dump-bin tables differ from the original dump", dumpFile)
+		}
+		gotEntry, err := smbios.ParseEntry(bytes.NewReader(got[:0x20]))
+		if err != nil {
+			t.Fatalf("%s: --dump-bin entry point: %v", dumpFile, err)
+		}
+		wantEntry, err := smbios.ParseEntry(bytes.NewReader(want[:0x20]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotEntry.String() != wantEntry.String() {
+			t.Errorf("%s: --dump-bin entry point is %s, want %s", dumpFile, gotEntry, wantEntry)
+		}
+		if addr, size := gotEntry.Table(); addr != 0x20 || size < len(got)-0x20 {
+			t.Errorf("%s: --dump-bin entry point table = 0x%x, %d bytes, want 0x20, %d bytes", dumpFile, addr, size, len(got)-0x20)
+		}
+	})
+}
+
 func TestDMIDecode(t *testing.T) {
 	bf, err := filepath.Glob("testdata/*.bin")
 	if err != nil {
@@ -67,10 +113,19 @@ func TestDMIDecode(t *testing.T) {
 	for _, dumpFile := range bf {
 		txtFile := strings.TrimSuffix(dumpFile, ".bin") + ".txt"
 		testOutput(t, bin, gocoverdir, dumpFile, nil, txtFile)
+		testDumpBinRoundTrip(t, bin, gocoverdir, dumpFile)
 	}
 
 	testOutput(t, bin, gocoverdir, "testdata/Asus-UX307LA.bin", []string{"-t", "system"}, "testdata/Asus-UX307LA.system.txt")
 	testOutput(t, bin, gocoverdir, "testdata/Asus-UX307LA.bin", []string{"-t", "1,131"}, "testdata/Asus-UX307LA.1_131.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Asus-UX307LA.bin", []string{"-H", "0x0002"}, "testdata/Asus-UX307LA.handle2.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Lenovo-ThinkPad-T480.bin", []string{"-u"}, "testdata/Lenovo-ThinkPad-T480.dump.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Asus-UX307LA.bin", []string{"-u", "-q", "-t", "1"}, "testdata/Asus-UX307LA.1.dump-quiet.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Lenovo-ThinkPad-T480.bin", []string{"-q"}, "testdata/Lenovo-ThinkPad-T480.quiet.txt")
+	testOutput(t, bin, gocoverdir, "testdata/SuperMicro-X9DBL.bin", []string{"-s", "processor-version"}, "testdata/SuperMicro-X9DBL.processor-version.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Gigabyte-GA-MA74GMT-S2.bin", []string{"-s", "system-uuid"}, "testdata/Gigabyte-GA-MA74GMT-S2.system-uuid.txt")
+	testOutput(t, bin, gocoverdir, "testdata/Asus-UX307LA.bin", []string{"--format", "json", "-t", "1,131"}, "testdata/Asus-UX307LA.1_131.json")
+	testOutput(t, bin, gocoverdir, "testdata/Lenovo-ThinkPad-T480.bin", []string{"--format", "json"}, "testdata/Lenovo-ThinkPad-T480.json")
 }
 
 func testDumpBin(t *testing.T, entryData, expectedOutData []byte) {
@@ -80,13 +135,17 @@ func testDumpBin(t *testing.T, entryData, expectedOutData []byte) {
 	if err != nil {
 		t.Fatalf("error creating temp file: %v", err)
 	}
+	// Existing contents must be truncated.
+	if _, err := tmpfile.Write(bytes.Repeat([]byte{0xff}, 64)); err != nil {
+		t.Fatalf("error writing temp file: %v", err)
+	}
 	tmpfile.Close()
 	defer os.Remove(tmpfile.Name())
 	textOut := bytes.NewBuffer(nil)
 	if err := dumpBin(
 		textOut,
 		entryData,
-		[]byte{0xaa, 0xbb}, // dummy
+		[]byte{0x7f, 0x04, 0x00, 0x00, 0x00, 0x00}, // End of table.
 		tmpfile.Name(),
 	); err != nil {
 		t.Fatalf("failed to dump bin: %v", err)
@@ -101,7 +160,8 @@ func testDumpBin(t *testing.T, entryData, expectedOutData []byte) {
 }
 
 func TestDMIDecodeDumpBin32(t *testing.T) {
-	// We expect entry point address to be rewritten and checksum adjusted.
+	// We expect entry point address, table length, maximum structure size and
+	// number of structures to be rewritten and checksums adjusted.
 	testDumpBin(
 		t,
 		[]byte{
@@ -109,15 +169,15 @@ func TestDMIDecodeDumpBin32(t *testing.T) {
 			0x5f, 0x44, 0x4d, 0x49, 0x5f, 0x37, 0x6e, 0x08, 0x00, 0x50, 0x7c, 0xac, 0x1b, 0x00, 0x28,
 		},
 		[]byte{
-			0x5f, 0x53, 0x4d, 0x5f, 0x64, 0x1f, 0x02, 0x08, 0x14, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0x5f, 0x44, 0x4d, 0x49, 0x5f, 0x8f, 0x6e, 0x08, 0x20, 0x00, 0x00, 0x00, 0x1b, 0x00, 0x28, 0x00,
-			0xaa, 0xbb,
+			0x5f, 0x53, 0x4d, 0x5f, 0x73, 0x1f, 0x02, 0x08, 0x06, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0x5f, 0x44, 0x4d, 0x49, 0x5f, 0x19, 0x06, 0x00, 0x20, 0x00, 0x00, 0x00, 0x01, 0x00, 0x28, 0x00,
+			0x7f, 0x04, 0x00, 0x00, 0x00, 0x00,
 		},
 	)
 }
 
 func TestDMIDecodeDumpBin64(t *testing.T) {
-	// We expect entry point address to be rewritten and checksum adjusted.
+	// We expect entry point address and table length to be rewritten and checksum adjusted.
 	testDumpBin(
 		t,
 		[]byte{
@@ -125,9 +185,163 @@ func TestDMIDecodeDumpBin64(t *testing.T) {
 			0x00, 0xe0, 0x10, 0x8f, 0x00, 0x00, 0x00, 0x00,
 		},
 		[]byte{
-			0x5f, 0x53, 0x4d, 0x33, 0x5f, 0x45, 0x18, 0x03, 0x00, 0x00, 0x01, 0x00, 0xe3, 0x0b, 0x00, 0x00,
+			0x5f, 0x53, 0x4d, 0x33, 0x5f, 0x2d, 0x18, 0x03, 0x00, 0x00, 0x01, 0x00, 0x06, 0x00, 0x00, 0x00,
 			0x20, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
-			0xaa, 0xbb,
+			0x7f, 0x04, 0x00, 0x00, 0x00, 0x00,
 		},
 	)
 }
+
+func TestStringKeywords(t *testing.T) {
+	entryData, tableData, err := getData(io.Discard, "testdata/Lenovo-ThinkPad-T480.bin", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	si, err := dmidecode.ParseInfo(entryData, tableData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		keyword string
+		want    string
+	}{
+		{"bios-vendor", "LENOVO\n"},
+		{"bios-version", "N22ET52W (1.29 )\n"},
+		{"bios-release-date", "01/16/2019\n"},
+		{"bios-revision", "1.29\n"},
+		{"firmware-revision", "1.11\n"},
+		{"system-manufacturer", "LENOVO\n"},
+		{"system-product-name", "20L8S07A14\n"},
+		{"system-version", "ThinkPad T480s\n"},
+		{"system-serial-number", "PC131TBF\n"},
+		{"system-uuid", "161674cc-2a1e-11b2-a85c-e121881d01b9\n"},
+		{"system-sku-number", "LENOVO_MT_20L8_BU_Think_FM_ThinkPad T480s\n"},
+		{"system-family", "ThinkPad T480s\n"},
+		{"baseboard-manufacturer", "LENOVO\n"},
+		{"baseboard-product-name", "20L8S07A14\n"},
+		{"baseboard-version", "SDK0J40697 WIN\n"},
+		{"baseboard-serial-number", "L1HF9380025\n"},
+		{"baseboard-asset-tag", "Not Available\n"},
+		{"chassis-manufacturer", "LENOVO\n"},
+		{"chassis-type", "Notebook\n"},
+		{"chassis-version", "None\n"},
+		{"chassis-serial-number", "PC131TBF\n"},
+		{"chassis-asset-tag", "No Asset Information\n"},
+		{"processor-family", "Core i7\n"},
+		{"processor-manufacturer", "Intel(R) Corporation\n"},
+		{"processor-version", "Intel(R) Core(TM) i7-8650U CPU @ 1.90GHz\n"},
+		{"processor-frequency", "1900 MHz\n"},
+		{"System-Serial-Number", "PC131TBF\n"},
+	} {
+		kw := findStringKeyword(tt.keyword)
+		if kw == nil {
+			t.Fatalf("findStringKeyword(%q) = nil", tt.keyword)
+		}
+		var out bytes.Buffer
+		printStringKeyword(&out, si, kw)
+		if got := out.String(); got != tt.want {
+			t.Errorf("--string %s = %q, want %q", tt.keyword, got, tt.want)
+		}
+	}
+	if kw := findStringKeyword("memory-size"); kw != nil {
+		t.Errorf("findStringKeyword(memory-size) = %v, want nil", kw.keyword)
+	}
+}
+
+// memImage returns an image of physical memory with the entry point e at base,
+// and the DMI table data followed by padding at the table address of e.
+func memImage(t *testing.T, e smbios.EntryPoint, base int64, tableData []byte, padding int) string {
+	t.Helper()
+	entryData, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, _ := e.Table()
+	mem := make([]byte, addr+len(tableData)+padding)
+	copy(mem[base:], entryData)
+	copy(mem[addr:], tableData)
+	for i := addr + len(tableData); i < len(mem); i++ {
+		mem[i] = 0xff
+	}
+	f := filepath.Join(t.TempDir(), "mem")
+	if err := os.WriteFile(f, mem, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestGetDataNoSysfs(t *testing.T) {
+	defer func(old string) { efiSystabPath = old }(efiSystabPath)
+	efiSystabPath = "testdata/systab_NOT_FOUND"
+	// Found by scanning the F0000 segment.
+	const base = 0xf0010
+
+	for _, dumpFile := range []string{"testdata/Asus-UX307LA.bin", "testdata/Lenovo-ThinkPad-T480.bin"} {
+		entryData, tableData, err := getData(io.Discard, dumpFile, "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		e, err := smbios.ParseEntry(bytes.NewReader(entryData))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch e := e.(type) {
+		case *smbios.Entry32:
+			e.StructTableAddr = 0x100000
+		case *smbios.Entry64:
+			// The table may be shorter than the maximum size.
+			e.StructTableAddr = 0x100000
+			e.StructMaxSize = uint32(len(tableData)) + 0x100
+		}
+		wantEntry, err := e.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		mem := memImage(t, e, base, tableData, 0x80)
+
+		var out bytes.Buffer
+		gotEntry, gotTable, err := getData(&out, "", "", "", mem)
+		if err != nil {
+			t.Fatalf("%s: getData(%s) = %v", dumpFile, mem, err)
+		}
+		if want := fmt.Sprintf("Reading SMBIOS/DMI data from %s.\n", mem); out.String() != want {
+			t.Errorf("%s: getData() output = %q, want %q", dumpFile, out.String(), want)
+		}
+		if !bytes.Equal(gotEntry, wantEntry) {
+			t.Errorf("%s: entry = %x, want %x", dumpFile, gotEntry, wantEntry)
+		}
+		if !bytes.Equal(gotTable, tableData) {
+			t.Errorf("%s: table data mismatch, got %d bytes, want %d", dumpFile, len(gotTable), len(tableData))
+		}
+	}
+}
+
+func TestGetDataFromImage(t *testing.T) {
+	entryData, tableData, err := getData(io.Discard, "testdata/Lenovo-ThinkPad-T480.bin", "", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The table is not where the entry point says, as in most images.
+	img := bytes.Repeat([]byte{0xff}, 0x10000)
+	copy(img[0x100:], entryData)
+	copy(img[0x8000:], tableData)
+	imageFile := filepath.Join(t.TempDir(), "bios.rom")
+	if err := os.WriteFile(imageFile, img, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	gotEntry, gotTable, err := getData(&out, "", imageFile, "", "")
+	if err != nil {
+		t.Fatalf("getData(%s) = %v", imageFile, err)
+	}
+	if want := fmt.Sprintf("Reading SMBIOS/DMI data from firmware image %s.\n", imageFile); out.String() != want {
+		t.Errorf("getData() output = %q, want %q", out.String(), want)
+	}
+	if !bytes.Equal(gotEntry, entryData[:len(gotEntry)]) {
+		t.Errorf("entry = %x, want %x", gotEntry, entryData)
+	}
+	if !bytes.Equal(gotTable, tableData) {
+		t.Errorf("table data mismatch, got %d bytes, want %d", len(gotTable), len(tableData))
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/dmidecode"
+)
+
+// Output formats supported by --format.
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// entryJSON is the JSON encoding of the entry point. Fields that the entry
+// point type does not have are omitted.
+type entryJSON struct {
+	Anchor            string
+	Version           string
+	StructTableAddr   int
+	StructTableLength *int    `json:",omitempty"`
+	StructMaxSize     *int    `json:",omitempty"`
+	NumberOfStructs   *uint16 `json:",omitempty"`
+}
+
+func newEntryJSON(e smbios.EntryPoint) entryJSON {
+	major, minor, rev := e.Version()
+	addr, size := e.Table()
+	ej := entryJSON{StructTableAddr: addr}
+	switch e := e.(type) {
+	case *smbios.Entry32:
+		maxSize := int(e.StructMaxSize)
+		ej.Anchor = string(e.Anchor[:])
+		ej.Version = fmt.Sprintf("%d.%d", major, minor)
+		ej.StructTableLength = &size
+		ej.StructMaxSize = &maxSize
+		ej.NumberOfStructs = &e.NumberOfStructs
+	case *smbios.EntryDMI:
+		ej.Anchor = string(e.Anchor[:])
+		ej.Version = fmt.Sprintf("%d.%d", major, minor)
+		ej.StructTableLength = &size
+		ej.NumberOfStructs = &e.NumberOfStructs
+	case *smbios.Entry64:
+		ej.Anchor = string(e.Anchor[:])
+		ej.Version = fmt.Sprintf("%d.%d.%d", major, minor, rev)
+		ej.StructMaxSize = &size
+	default:
+		ej.Version = fmt.Sprintf("%d.%d.%d", major, minor, rev)
+		ej.StructMaxSize = &size
+	}
+	return ej
+}
+
+// tableJSON marshals a table with dmidecode.MarshalTableJSON if the type is
+// supported, and with dmidecode.MarshalRawTableJSON otherwise. Like the text
+// output, tables that fail to parse are reported raw along with the error.
+func tableJSON(t *smbios.Table) (json.RawMessage, error) {
+	pt, err := dmidecode.ParseTypedTable(t)
+	switch {
+	case err == nil:
+		return dmidecode.MarshalTableJSON(pt)
+	case errors.Is(err, dmidecode.ErrUnsupportedTableType):
+		return dmidecode.MarshalRawTableJSON(t, nil)
+	}
+	return dmidecode.MarshalRawTableJSON(t, err)
+}
+
+// writeJSON writes the entry point and all tables for which include returns true as JSON.
+func writeJSON(w io.Writer, si *dmidecode.Info, include func(*smbios.Table) bool) error {
+	tables := []json.RawMessage{}
+	for _, t := range si.Tables {
+		if !include(t) {
+			continue
+		}
+		tj, err := tableJSON(t)
+		if err != nil {
+			return fmt.Errorf("table 0x%04X: %w", t.Handle, err)
+		}
+		tables = append(tables, tj)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Entry  entryJSON
+		Tables []json.RawMessage
+	}{newEntryJSON(si.Entry), tables})
+}
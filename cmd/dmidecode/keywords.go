@@ -0,0 +1,165 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/u-root/smbios/dmidecode"
+)
+
+// stringKeyword is a --string keyword, which selects a single field of a table.
+// As in dmidecode(8), the field is only printed if offset, its offset in the
+// table, is within the table length.
+type stringKeyword struct {
+	keyword string
+	offset  uint8
+	values  func(si *dmidecode.Info, offset uint8) []string
+}
+
+func stringOrNotSpecified(s string) string {
+	if s == "" {
+		return "Not Specified"
+	}
+	return s
+}
+
+func freqOrUnknown(v uint16) string {
+	if v == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d MHz", v)
+}
+
+func revisionOrNone(major, minor uint8) []string {
+	if major == 0xff || minor == 0xff {
+		return nil
+	}
+	return []string{fmt.Sprintf("%d.%d", major, minor)}
+}
+
+// The functions below look up tables with the typed Info getters. Errors are
+// not reported, just like dmidecode(8) is quiet in --string mode.
+
+func biosKeyword(f func(bi *dmidecode.BIOSInfo) []string) func(*dmidecode.Info, uint8) []string {
+	return func(si *dmidecode.Info, offset uint8) []string {
+		bi, err := si.GetBIOSInfo()
+		if err != nil || bi.Length <= offset {
+			return nil
+		}
+		return f(bi)
+	}
+}
+
+func systemKeyword(f func(si *dmidecode.SystemInfo) string) func(*dmidecode.Info, uint8) []string {
+	return func(info *dmidecode.Info, offset uint8) []string {
+		si, err := info.GetSystemInfo()
+		if err != nil || si.Length <= offset {
+			return nil
+		}
+		return []string{f(si)}
+	}
+}
+
+func baseboardKeyword(f func(bi *dmidecode.BaseboardInfo) string) func(*dmidecode.Info, uint8) []string {
+	return func(si *dmidecode.Info, offset uint8) []string {
+		bis, _ := si.GetBaseboardInfo()
+		var res []string
+		for _, bi := range bis {
+			if bi.Length > offset {
+				res = append(res, f(bi))
+			}
+		}
+		return res
+	}
+}
+
+func chassisKeyword(f func(ci *dmidecode.ChassisInfo) string) func(*dmidecode.Info, uint8) []string {
+	return func(si *dmidecode.Info, offset uint8) []string {
+		cis, _ := si.GetChassisInfo()
+		var res []string
+		for _, ci := range cis {
+			if ci.Length > offset {
+				res = append(res, f(ci))
+			}
+		}
+		return res
+	}
+}
+
+func processorKeyword(f func(pi *dmidecode.ProcessorInfo) string) func(*dmidecode.Info, uint8) []string {
+	return func(si *dmidecode.Info, offset uint8) []string {
+		pis, _ := si.GetProcessorInfo()
+		var res []string
+		for _, pi := range pis {
+			if pi.Length > offset {
+				res = append(res, f(pi))
+			}
+		}
+		return res
+	}
+}
+
+// stringKeywords are the keywords supported by dmidecode(8) --string, in the same order.
+var stringKeywords = []stringKeyword{
+	{"bios-vendor", 0x04, biosKeyword(func(bi *dmidecode.BIOSInfo) []string { return []string{stringOrNotSpecified(bi.Vendor)} })},
+	{"bios-version", 0x05, biosKeyword(func(bi *dmidecode.BIOSInfo) []string { return []string{stringOrNotSpecified(bi.Version)} })},
+	{"bios-release-date", 0x08, biosKeyword(func(bi *dmidecode.BIOSInfo) []string { return []string{stringOrNotSpecified(bi.ReleaseDate)} })},
+	{"bios-revision", 0x15, biosKeyword(func(bi *dmidecode.BIOSInfo) []string { return revisionOrNone(bi.BIOSMajor, bi.BIOSMinor) })},
+	{"firmware-revision", 0x17, biosKeyword(func(bi *dmidecode.BIOSInfo) []string { return revisionOrNone(bi.ECMajor, bi.ECMinor) })},
+	{"system-manufacturer", 0x04, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.Manufacturer) })},
+	{"system-product-name", 0x05, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.ProductName) })},
+	{"system-version", 0x06, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.Version) })},
+	{"system-serial-number", 0x07, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.SerialNumber) })},
+	{"system-uuid", 0x08, systemKeyword(func(si *dmidecode.SystemInfo) string { return si.UUIDString() })},
+	{"system-sku-number", 0x19, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.SKUNumber) })},
+	{"system-family", 0x1a, systemKeyword(func(si *dmidecode.SystemInfo) string { return stringOrNotSpecified(si.Family) })},
+	{"baseboard-manufacturer", 0x04, baseboardKeyword(func(bi *dmidecode.BaseboardInfo) string { return stringOrNotSpecified(bi.Manufacturer) })},
+	{"baseboard-product-name", 0x05, baseboardKeyword(func(bi *dmidecode.BaseboardInfo) string { return stringOrNotSpecified(bi.Product) })},
+	{"baseboard-version", 0x06, baseboardKeyword(func(bi *dmidecode.BaseboardInfo) string { return stringOrNotSpecified(bi.Version) })},
+	{"baseboard-serial-number", 0x07, baseboardKeyword(func(bi *dmidecode.BaseboardInfo) string { return stringOrNotSpecified(bi.SerialNumber) })},
+	{"baseboard-asset-tag", 0x08, baseboardKeyword(func(bi *dmidecode.BaseboardInfo) string { return stringOrNotSpecified(bi.AssetTag) })},
+	{"chassis-manufacturer", 0x04, chassisKeyword(func(ci *dmidecode.ChassisInfo) string { return stringOrNotSpecified(ci.Manufacturer) })},
+	{"chassis-type", 0x05, chassisKeyword(func(ci *dmidecode.ChassisInfo) string { return ci.Type.String() })},
+	{"chassis-version", 0x06, chassisKeyword(func(ci *dmidecode.ChassisInfo) string { return stringOrNotSpecified(ci.Version) })},
+	{"chassis-serial-number", 0x07, chassisKeyword(func(ci *dmidecode.ChassisInfo) string { return stringOrNotSpecified(ci.SerialNumber) })},
+	{"chassis-asset-tag", 0x08, chassisKeyword(func(ci *dmidecode.ChassisInfo) string { return stringOrNotSpecified(ci.AssetTagNumber) })},
+	{"processor-family", 0x06, processorKeyword(func(pi *dmidecode.ProcessorInfo) string { return pi.GetFamily().String() })},
+	{"processor-manufacturer", 0x07, processorKeyword(func(pi *dmidecode.ProcessorInfo) string { return stringOrNotSpecified(pi.Manufacturer) })},
+	{"processor-version", 0x10, processorKeyword(func(pi *dmidecode.ProcessorInfo) string { return stringOrNotSpecified(pi.Version) })},
+	{"processor-frequency", 0x16, processorKeyword(func(pi *dmidecode.ProcessorInfo) string { return freqOrUnknown(pi.CurrentSpeed) })},
+}
+
+func findStringKeyword(keyword string) *stringKeyword {
+	for i := range stringKeywords {
+		if stringKeywords[i].keyword == strings.ToLower(keyword) {
+			return &stringKeywords[i]
+		}
+	}
+	return nil
+}
+
+// stringKeywordsUsage returns the error message for an invalid --string keyword.
+func stringKeywordsUsage(keyword string) string {
+	lines := []string{
+		fmt.Sprintf("Invalid string keyword: %s", keyword),
+		"Valid string keywords are:",
+	}
+	for _, kw := range stringKeywords {
+		lines = append(lines, "  "+kw.keyword)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// printStringKeyword prints the value of the keyword, one line per table instance.
+func printStringKeyword(w io.Writer, si *dmidecode.Info, kw *stringKeyword) {
+	for _, v := range kw.values(si, kw.offset) {
+		fmt.Fprintf(w, "%s\n", v)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrTableOutOfRange is returned when the structure table cannot be described
+// by the requested entry point, because of its address, size or number of
+// structures.
+var ErrTableOutOfRange = errors.New("structure table out of range of entry point")
+
+// Entry point revisions, DSP0134 5.2.1 and 5.2.2.
+const (
+	entry32Revision = 0x00 // SMBIOS 2.1 entry point, formatted area reserved.
+	entry64Revision = 0x01 // SMBIOS 3.0 entry point.
+)
+
+// bcdRevision returns the BCD revision of a 32-bit entry point. It is 0 if the
+// version does not fit in BCD, in which case only the major and minor version
+// fields are meaningful.
+func bcdRevision(major, minor uint8) uint8 {
+	if major > 9 || minor > 9 {
+		return 0
+	}
+	return major<<4 | minor
+}
+
+// NewEntry32 returns an SMBIOS 32-bit entry point for version major.minor
+// pointing at tables located at the physical address addr. The table length,
+// maximum structure size, number of structures and checksums are derived from
+// tables.
+//
+// ErrTableOutOfRange is returned if the tables are above 4 GiB, larger than
+// 65535 bytes or consist of more than 65535 structures.
+func NewEntry32(major, minor uint8, addr uint64, tables Tables) (*Entry32, error) {
+	if addr > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: address 0x%x above 4 GiB", ErrTableOutOfRange, addr)
+	}
+	if len(tables) > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: %d structures", ErrTableOutOfRange, len(tables))
+	}
+	var length, maxSize int
+	for _, t := range tables {
+		data, err := t.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("table handle 0x%04x: %w", t.Handle, err)
+		}
+		length += len(data)
+		maxSize = max(maxSize, len(data))
+	}
+	if length > math.MaxUint16 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTableOutOfRange, length)
+	}
+
+	e := &Entry32{
+		Length:            smbios2HeaderSize,
+		MajorVersion:      major,
+		MinorVersion:      minor,
+		StructMaxSize:     uint16(maxSize),
+		Revision:          entry32Revision,
+		StructTableLength: uint16(length),
+		StructTableAddr:   uint32(addr),
+		NumberOfStructs:   uint16(len(tables)),
+		BCDRevision:       bcdRevision(major, minor),
+	}
+	copy(e.Anchor[:], anchor32)
+	copy(e.IntAnchor[:], anchorDMI)
+	data, err := e.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	e.Checksum, e.IntChecksum = data[4], data[0x15]
+	return e, nil
+}
+
+// NewEntry64 returns an SMBIOS 64-bit entry point for version
+// major.minor.docrev pointing at tables located at the physical address addr.
+// The maximum table size and checksum are derived from tables.
+func NewEntry64(major, minor, docrev uint8, addr uint64, tables Tables) (*Entry64, error) {
+	data, err := tables.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTableOutOfRange, len(data))
+	}
+
+	e := &Entry64{
+		Length:          smbios3HeaderSize,
+		MajorVersion:    major,
+		MinorVersion:    minor,
+		DocRev:          docrev,
+		Revision:        entry64Revision,
+		StructMaxSize:   uint32(len(data)),
+		StructTableAddr: addr,
+	}
+	copy(e.Anchor[:], anchor64)
+	edata, err := e.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	e.Checksum = edata[5]
+	return e, nil
+}
+
+// ToEntry32 converts e into a 32-bit entry point for tables, keeping the
+// version and table address of e. The docrev of a 64-bit entry point has no
+// equivalent and is dropped. See NewEntry32 for the limits that apply.
+func ToEntry32(e EntryPoint, tables Tables) (*Entry32, error) {
+	major, minor, _ := e.Version()
+	addr, _ := e.Table()
+	return NewEntry32(uint8(major), uint8(minor), uint64(addr), tables)
+}
+
+// ToEntry64 converts e into a 64-bit entry point for tables, keeping the
+// version and table address of e.
+//
+// The version is kept even if it predates SMBIOS 3.0, as it describes the
+// format of the tables rather than that of the entry point.
+func ToEntry64(e EntryPoint, tables Tables) (*Entry64, error) {
+	major, minor, rev := e.Version()
+	addr, _ := e.Table()
+	return NewEntry64(uint8(major), uint8(minor), uint8(rev), uint64(addr), tables)
+}
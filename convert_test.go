@@ -0,0 +1,149 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTablesMarshalBinary(t *testing.T) {
+	_, data := testMemTables(t)
+	tables, err := ParseTables(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tables.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() = %v", err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("MarshalBinary() does not reproduce the parsed table data")
+	}
+
+	for _, tt := range []struct {
+		name  string
+		table *Table
+	}{
+		{"length mismatch", &Table{Header: Header{Length: 5}}},
+		{"empty string", &Table{Header: Header{Length: 4}, Strings: []string{""}}},
+		{"NUL in string", &Table{Header: Header{Length: 4}, Strings: []string{"a\x00b"}}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.table.MarshalBinary(); err == nil {
+				t.Errorf("MarshalBinary() = nil, want error")
+			}
+		})
+	}
+}
+
+func TestConvertEntry(t *testing.T) {
+	e32, data := testMemTables(t)
+	tables, err := ParseTables(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e64, err := ToEntry64(e32, tables)
+	if err != nil {
+		t.Fatalf("ToEntry64() = %v", err)
+	}
+	want64 := &Entry64{
+		Anchor:          [5]uint8{'_', 'S', 'M', '3', '_'},
+		Length:          0x18,
+		MajorVersion:    e32.MajorVersion,
+		MinorVersion:    e32.MinorVersion,
+		Revision:        1,
+		StructMaxSize:   uint32(len(data)),
+		StructTableAddr: uint64(e32.StructTableAddr),
+	}
+	edata, err := e64.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want64.Checksum = edata[5]
+	if !reflect.DeepEqual(e64, want64) {
+		t.Errorf("ToEntry64() = %+v, want %+v", e64, want64)
+	}
+	var u64 Entry64
+	if err := u64.UnmarshalBinary(edata); err != nil {
+		t.Errorf("UnmarshalBinary(ToEntry64()) = %v", err)
+	}
+
+	// Converting back yields the original entry point.
+	got32, err := ToEntry32(e64, tables)
+	if err != nil {
+		t.Fatalf("ToEntry32() = %v", err)
+	}
+	if !reflect.DeepEqual(got32, e32) {
+		t.Errorf("ToEntry32(ToEntry64()) = %+v, want %+v", got32, e32)
+	}
+
+	dmi := &EntryDMI{StructTableAddr: 0x1000, BCDRevision: 0x20}
+	got32, err = ToEntry32(dmi, tables)
+	if err != nil {
+		t.Fatalf("ToEntry32(%v) = %v", dmi, err)
+	}
+	if got32.MajorVersion != 2 || got32.MinorVersion != 0 || got32.BCDRevision != 0x20 || got32.StructTableAddr != 0x1000 {
+		t.Errorf("ToEntry32(%v) = %+v", dmi, got32)
+	}
+	edata, err = got32.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u32 Entry32
+	if err := u32.UnmarshalBinary(edata); err != nil {
+		t.Errorf("UnmarshalBinary(ToEntry32()) = %v", err)
+	}
+}
+
+func TestNewEntry32OutOfRange(t *testing.T) {
+	big := &Table{
+		Header: Header{Length: 4},
+		// Strings are limited by nothing but the table size.
+		Strings: []string{string(bytes.Repeat([]byte{'a'}, math.MaxUint16))},
+	}
+	for _, tt := range []struct {
+		name   string
+		addr   uint64
+		tables Tables
+	}{
+		{"above 4 GiB", 1 << 32, nil},
+		{"too large", 0x1000, Tables{big}},
+		{"too many structures", 0x1000, make(Tables, math.MaxUint16+1)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewEntry32(3, 0, tt.addr, tt.tables); !errors.Is(err, ErrTableOutOfRange) {
+				t.Errorf("NewEntry32() = %v, want %v", err, ErrTableOutOfRange)
+			}
+		})
+	}
+
+	e, err := NewEntry64(3, 0, 0, 1<<32, Tables{big})
+	if err != nil {
+		t.Fatalf("NewEntry64() = %v", err)
+	}
+	if e.StructTableAddr != 1<<32 || e.StructMaxSize != 4+math.MaxUint16+2 {
+		t.Errorf("NewEntry64() = %+v", e)
+	}
+}
+
+func TestBCDRevision(t *testing.T) {
+	for _, tt := range []struct {
+		major, minor, want uint8
+	}{
+		{2, 1, 0x21},
+		{3, 9, 0x39},
+		{3, 10, 0},
+		{10, 0, 0},
+	} {
+		if got := bcdRevision(tt.major, tt.minor); got != tt.want {
+			t.Errorf("bcdRevision(%d, %d) = %#x, want %#x", tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors for coreboot tables.
+var (
+	ErrCorebootTableNotFound = errors.New("coreboot table not found")
+	ErrInvalidCorebootTable  = errors.New("invalid coreboot table")
+	ErrNoSMBIOSInCBMEM       = errors.New("no SMBIOS entry in CBMEM")
+)
+
+const (
+	lbHeaderSize = 24
+	lbRecordSize = 8
+
+	// Record tags, from coreboot's commonlib/coreboot_tables.h.
+	lbTagForward    = 0x11
+	lbTagCBMEMEntry = 0x31
+
+	// Sanity limits for the table size and the number of forward records.
+	lbMaxTableBytes = 1 << 20
+	lbMaxForwards   = 4
+
+	// cbmemSMBIOSScanBytes is how much of the SMBIOS CBMEM region is searched
+	// for entry points, which coreboot writes at the start of the region.
+	cbmemSMBIOSScanBytes = 0x100
+)
+
+// CBMEMIDSMBIOS is the CBMEM ID of the SMBIOS region, from coreboot's
+// commonlib/bsd/cbmem_id.h.
+const CBMEMIDSMBIOS = 0x534d4254 // "SMBT"
+
+// lbHeader is the coreboot table header.
+type lbHeader struct {
+	Signature      [4]byte
+	HeaderBytes    uint32
+	HeaderChecksum uint32
+	TableBytes     uint32
+	TableChecksum  uint32
+	TableEntries   uint32
+}
+
+// CBMEMEntry is a region of CBMEM, as listed in the coreboot table.
+type CBMEMEntry struct {
+	ID      uint32
+	Address uint64
+	Size    uint32
+}
+
+// ipChecksum is the 16-bit one's complement checksum used by coreboot tables.
+func ipChecksum(data []byte) uint16 {
+	var sum uint32
+	for i, b := range data {
+		v := uint32(b)
+		if i&1 == 1 {
+			v <<= 8
+		}
+		sum += v
+		if sum > 0xffff {
+			sum = (sum + sum>>16) & 0xffff
+		}
+	}
+	return ^uint16(sum)
+}
+
+// FindCorebootTable searches the coreboot table header ("LBIO") in the low
+// memory regions where coreboot places it, 0-0x1000 and 0xf0000-0x100000, and
+// returns its address.
+func FindCorebootTable(mem io.ReaderAt) (int64, error) {
+	b := make([]byte, lbHeaderSize)
+	for _, r := range []struct{ start, end int64 }{{0, 0x1000}, {0xf0000, 0x100000}} {
+		for addr := r.start; addr < r.end; addr += 16 {
+			if _, err := mem.ReadAt(b, addr); err != nil {
+				// Memory images may not cover both regions.
+				break
+			}
+			if bytes.Equal(b[:4], []byte("LBIO")) && ipChecksum(b) == 0 {
+				return addr, nil
+			}
+		}
+	}
+	return 0, ErrCorebootTableNotFound
+}
+
+// CorebootCBMEMEntries walks the records of the coreboot table at addr in
+// mem, following forward records, and returns the CBMEM entries it lists.
+func CorebootCBMEMEntries(mem io.ReaderAt, addr int64) ([]CBMEMEntry, error) {
+	for forwards := 0; forwards <= lbMaxForwards; forwards++ {
+		var h lbHeader
+		if err := binary.Read(io.NewSectionReader(mem, addr, lbHeaderSize), binary.LittleEndian, &h); err != nil {
+			return nil, fmt.Errorf("error reading coreboot table header at 0x%x: %w", addr, convertUnexpectedEOF(err))
+		}
+		if string(h.Signature[:]) != "LBIO" || h.HeaderBytes < lbHeaderSize {
+			return nil, fmt.Errorf("%w: bad header at 0x%x", ErrInvalidCorebootTable, addr)
+		}
+		if h.TableBytes > lbMaxTableBytes {
+			return nil, fmt.Errorf("%w: table size %d", ErrInvalidCorebootTable, h.TableBytes)
+		}
+		table := make([]byte, h.TableBytes)
+		if _, err := mem.ReadAt(table, addr+int64(h.HeaderBytes)); err != nil {
+			return nil, fmt.Errorf("error reading coreboot table at 0x%x: %w", addr, convertUnexpectedEOF(err))
+		}
+		if cs := ipChecksum(table); uint32(cs) != h.TableChecksum {
+			return nil, fmt.Errorf("%w: table checksum mismatch: 0x%04x vs 0x%04x", ErrInvalidCorebootTable, h.TableChecksum, cs)
+		}
+
+		var entries []CBMEMEntry
+		forward := int64(-1)
+		for i, off := uint32(0), 0; i < h.TableEntries; i++ {
+			if off+lbRecordSize > len(table) {
+				return nil, fmt.Errorf("%w: record %d beyond end of table", ErrInvalidCorebootTable, i)
+			}
+			tag := binary.LittleEndian.Uint32(table[off:])
+			size := int(binary.LittleEndian.Uint32(table[off+4:]))
+			if size < lbRecordSize || off+size > len(table) {
+				return nil, fmt.Errorf("%w: record %d has invalid size %d", ErrInvalidCorebootTable, i, size)
+			}
+			rec := table[off : off+size]
+			switch tag {
+			case lbTagForward:
+				if size >= 16 {
+					forward = int64(binary.LittleEndian.Uint64(rec[8:]))
+				}
+			case lbTagCBMEMEntry:
+				if size >= 24 {
+					entries = append(entries, CBMEMEntry{
+						Address: binary.LittleEndian.Uint64(rec[8:]),
+						Size:    binary.LittleEndian.Uint32(rec[16:]),
+						ID:      binary.LittleEndian.Uint32(rec[20:]),
+					})
+				}
+			}
+			off += size
+		}
+		if forward < 0 {
+			return entries, nil
+		}
+		// The table in low memory only points to the real one.
+		addr = forward
+	}
+	return nil, fmt.Errorf("%w: too many forward records", ErrInvalidCorebootTable)
+}
+
+// EntryBaseFromCoreboot finds the SMBIOS entry point address in the SMBIOS
+// CBMEM region listed in the coreboot table in mem.
+//
+// The returned size is the size of the entry point structure, as with EntryBase.
+func EntryBaseFromCoreboot(mem io.ReaderAt) (base int64, size int64, err error) {
+	lb, err := FindCorebootTable(mem)
+	if err != nil {
+		return 0, 0, err
+	}
+	entries, err := CorebootCBMEMEntries(mem, lb)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, e := range entries {
+		if e.ID == CBMEMIDSMBIOS {
+			// The region starts with the entry points, followed by the
+			// tables. The size comes from memory, do not trust it further.
+			size := int64(e.Size)
+			if size > cbmemSMBIOSScanBytes {
+				size = cbmemSMBIOSScanBytes
+			}
+			return getMemBase(mem, int64(e.Address), int64(e.Address)+size)
+		}
+	}
+	return 0, 0, ErrNoSMBIOSInCBMEM
+}
+
+// FromCoreboot parses the SMBIOS entry point and tables that coreboot placed
+// in CBMEM, in mem, an image of physical memory such as /dev/mem.
+func FromCoreboot(mem io.ReaderAt) (EntryPoint, Tables, error) {
+	base, _, err := EntryBaseFromCoreboot(mem)
+	if err != nil {
+		return nil, nil, err
+	}
+	return FromMemoryAt(mem, base)
+}
@@ -0,0 +1,162 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// lbRecord returns a coreboot table record with the given tag and payload.
+func lbRecord(tag uint32, payload ...interface{}) []byte {
+	var b bytes.Buffer
+	for _, p := range payload {
+		_ = binary.Write(&b, binary.LittleEndian, p)
+	}
+	rec := binary.LittleEndian.AppendUint32(nil, tag)
+	rec = binary.LittleEndian.AppendUint32(rec, uint32(lbRecordSize+b.Len()))
+	return append(rec, b.Bytes()...)
+}
+
+// lbTable writes a coreboot table with the given records at addr in mem.
+func lbTable(mem []byte, addr int, records ...[]byte) {
+	table := bytes.Join(records, nil)
+	h := lbHeader{
+		Signature:     [4]byte{'L', 'B', 'I', 'O'},
+		HeaderBytes:   lbHeaderSize,
+		TableBytes:    uint32(len(table)),
+		TableChecksum: uint32(ipChecksum(table)),
+		TableEntries:  uint32(len(records)),
+	}
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.LittleEndian, h)
+	hdr := b.Bytes()
+	binary.LittleEndian.PutUint32(hdr[8:], uint32(ipChecksum(hdr)))
+	copy(mem[addr:], hdr)
+	copy(mem[addr+lbHeaderSize:], table)
+}
+
+// cbmemIDRoot is the CBMEM ID of the CBMEM root, which lists all regions.
+const cbmemIDRoot = 0xff4007ff
+
+// corebootImage returns a memory image with a coreboot table in low memory
+// forwarding to the real one, which lists an SMBIOS CBMEM region with the
+// test tables.
+func corebootImage(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+	e, table := testMemTables(t)
+	// coreboot puts the entry point at the start of the region, and the
+	// tables right after it.
+	e.StructTableAddr = 0x20020
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := make([]byte, 0x22000)
+	copy(mem[0x20000:], entry)
+	copy(mem[0x20020:], table)
+
+	lbTable(mem, 0x500, lbRecord(lbTagForward, uint64(0x10000)))
+	lbTable(mem, 0x10000,
+		lbRecord(0x1, uint64(0), uint64(0x1000), uint32(1)),
+		lbRecord(lbTagCBMEMEntry, uint64(0x18000), uint32(0x1000), uint32(cbmemIDRoot)),
+		lbRecord(lbTagCBMEMEntry, uint64(0x20000), uint32(0x2000), uint32(CBMEMIDSMBIOS)),
+	)
+	return mem, table
+}
+
+func TestIPChecksum(t *testing.T) {
+	for _, tt := range []struct {
+		data []byte
+		want uint16
+	}{
+		{nil, 0xffff},
+		{[]byte{0x01, 0x02}, ^uint16(0x0201)},
+		{[]byte{0xff, 0xff, 0x01}, ^uint16(0x0001)},
+	} {
+		if got := ipChecksum(tt.data); got != tt.want {
+			t.Errorf("ipChecksum(%x) = 0x%04x, want 0x%04x", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestCorebootCBMEMEntries(t *testing.T) {
+	mem, _ := corebootImage(t)
+	addr, err := FindCorebootTable(bytes.NewReader(mem))
+	if err != nil {
+		t.Fatalf("FindCorebootTable() = %v", err)
+	}
+	if addr != 0x500 {
+		t.Errorf("FindCorebootTable() = 0x%x, want 0x500", addr)
+	}
+	entries, err := CorebootCBMEMEntries(bytes.NewReader(mem), addr)
+	if err != nil {
+		t.Fatalf("CorebootCBMEMEntries() = %v", err)
+	}
+	want := []CBMEMEntry{
+		{ID: cbmemIDRoot, Address: 0x18000, Size: 0x1000},
+		{ID: CBMEMIDSMBIOS, Address: 0x20000, Size: 0x2000},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("CorebootCBMEMEntries() = %+v, want %+v", entries, want)
+	}
+}
+
+func TestFromCoreboot(t *testing.T) {
+	mem, _ := corebootImage(t)
+	e, tables, err := FromCoreboot(bytes.NewReader(mem))
+	if err != nil {
+		t.Fatalf("FromCoreboot() = %v", err)
+	}
+	if e.String() != "SMBIOS 2.7" {
+		t.Errorf("FromCoreboot() entry = %s, want SMBIOS 2.7", e)
+	}
+	if n := int(e.(*Entry32).NumberOfStructs); len(tables) != n {
+		t.Errorf("FromCoreboot() = %d tables, want %d", len(tables), n)
+	}
+}
+
+func TestEntryBaseFromCorebootLargeRegion(t *testing.T) {
+	mem, _ := corebootImage(t)
+	// Only the start of the region is searched, whatever size it claims.
+	lbTable(mem, 0x10000, lbRecord(lbTagCBMEMEntry, uint64(0x20000), uint32(0xffffffff), uint32(CBMEMIDSMBIOS)))
+	base, _, err := EntryBaseFromCoreboot(bytes.NewReader(mem))
+	if err != nil {
+		t.Fatalf("EntryBaseFromCoreboot() = %v", err)
+	}
+	if base != 0x20000 {
+		t.Errorf("EntryBaseFromCoreboot() = 0x%x, want 0x20000", base)
+	}
+}
+
+func TestCorebootErrors(t *testing.T) {
+	mem, _ := corebootImage(t)
+
+	if _, err := FindCorebootTable(bytes.NewReader(mem[:0x500])); !errors.Is(err, ErrCorebootTableNotFound) {
+		t.Errorf("no table: FindCorebootTable() = %v, want %v", err, ErrCorebootTableNotFound)
+	}
+
+	bad := append([]byte{}, mem...)
+	bad[0x10000+lbHeaderSize+8]++
+	if _, err := CorebootCBMEMEntries(bytes.NewReader(bad), 0x500); !errors.Is(err, ErrInvalidCorebootTable) {
+		t.Errorf("bad table checksum: CorebootCBMEMEntries() = %v, want %v", err, ErrInvalidCorebootTable)
+	}
+
+	// A forward loop.
+	bad = append([]byte{}, mem...)
+	lbTable(bad, 0x10000, lbRecord(lbTagForward, uint64(0x500)))
+	if _, err := CorebootCBMEMEntries(bytes.NewReader(bad), 0x500); !errors.Is(err, ErrInvalidCorebootTable) {
+		t.Errorf("forward loop: CorebootCBMEMEntries() = %v, want %v", err, ErrInvalidCorebootTable)
+	}
+
+	bad = append([]byte{}, mem...)
+	lbTable(bad, 0x10000, lbRecord(lbTagCBMEMEntry, uint64(0x18000), uint32(0x1000), uint32(cbmemIDRoot)))
+	if _, _, err := EntryBaseFromCoreboot(bytes.NewReader(bad)); !errors.Is(err, ErrNoSMBIOSInCBMEM) {
+		t.Errorf("no SMBIOS entry: EntryBaseFromCoreboot() = %v, want %v", err, ErrNoSMBIOSInCBMEM)
+	}
+}
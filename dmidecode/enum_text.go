@@ -0,0 +1,279 @@
+// Code generated by "go run gen_enum_text.go"; DO NOT EDIT.
+
+package dmidecode
+
+var wakeupTypeNames enumNames[WakeupType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v WakeupType) MarshalText() ([]byte, error) {
+	return wakeupTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *WakeupType) UnmarshalText(text []byte) error {
+	return wakeupTypeNames.unmarshalText(text, v)
+}
+
+var boardTypeNames enumNames[BoardType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v BoardType) MarshalText() ([]byte, error) {
+	return boardTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *BoardType) UnmarshalText(text []byte) error {
+	return boardTypeNames.unmarshalText(text, v)
+}
+
+var chassisTypeNames enumNames[ChassisType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ChassisType) MarshalText() ([]byte, error) {
+	return chassisTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ChassisType) UnmarshalText(text []byte) error {
+	return chassisTypeNames.unmarshalText(text, v)
+}
+
+var chassisStateNames enumNames[ChassisState]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ChassisState) MarshalText() ([]byte, error) {
+	return chassisStateNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ChassisState) UnmarshalText(text []byte) error {
+	return chassisStateNames.unmarshalText(text, v)
+}
+
+var chassisSecurityStatusNames enumNames[ChassisSecurityStatus]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ChassisSecurityStatus) MarshalText() ([]byte, error) {
+	return chassisSecurityStatusNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ChassisSecurityStatus) UnmarshalText(text []byte) error {
+	return chassisSecurityStatusNames.unmarshalText(text, v)
+}
+
+var chassisElementTypeNames enumNames[ChassisElementType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ChassisElementType) MarshalText() ([]byte, error) {
+	return chassisElementTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ChassisElementType) UnmarshalText(text []byte) error {
+	return chassisElementTypeNames.unmarshalText(text, v)
+}
+
+var chassisRackTypeNames enumNames[ChassisRackType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ChassisRackType) MarshalText() ([]byte, error) {
+	return chassisRackTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ChassisRackType) UnmarshalText(text []byte) error {
+	return chassisRackTypeNames.unmarshalText(text, v)
+}
+
+var processorTypeNames enumNames[ProcessorType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ProcessorType) MarshalText() ([]byte, error) {
+	return processorTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorType) UnmarshalText(text []byte) error {
+	return processorTypeNames.unmarshalText(text, v)
+}
+
+var processorFamilyNames enumNames[ProcessorFamily]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ProcessorFamily) MarshalText() ([]byte, error) {
+	return processorFamilyNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorFamily) UnmarshalText(text []byte) error {
+	return processorFamilyNames.unmarshalText(text, v)
+}
+
+var processorStatusNames enumNames[ProcessorStatus]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ProcessorStatus) MarshalText() ([]byte, error) {
+	return processorStatusNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorStatus) UnmarshalText(text []byte) error {
+	return processorStatusNames.unmarshalText(text, v)
+}
+
+var processorUpgradeNames enumNames[ProcessorUpgrade]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ProcessorUpgrade) MarshalText() ([]byte, error) {
+	return processorUpgradeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorUpgrade) UnmarshalText(text []byte) error {
+	return processorUpgradeNames.unmarshalText(text, v)
+}
+
+var cacheOperationalModeNames enumNames[CacheOperationalMode]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v CacheOperationalMode) MarshalText() ([]byte, error) {
+	return cacheOperationalModeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *CacheOperationalMode) UnmarshalText(text []byte) error {
+	return cacheOperationalModeNames.unmarshalText(text, v)
+}
+
+var cacheLocationNames enumNames[CacheLocation]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v CacheLocation) MarshalText() ([]byte, error) {
+	return cacheLocationNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *CacheLocation) UnmarshalText(text []byte) error {
+	return cacheLocationNames.unmarshalText(text, v)
+}
+
+var cacheErrorCorrectionTypeNames enumNames[CacheErrorCorrectionType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v CacheErrorCorrectionType) MarshalText() ([]byte, error) {
+	return cacheErrorCorrectionTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *CacheErrorCorrectionType) UnmarshalText(text []byte) error {
+	return cacheErrorCorrectionTypeNames.unmarshalText(text, v)
+}
+
+var cacheSystemTypeNames enumNames[CacheSystemType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v CacheSystemType) MarshalText() ([]byte, error) {
+	return cacheSystemTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *CacheSystemType) UnmarshalText(text []byte) error {
+	return cacheSystemTypeNames.unmarshalText(text, v)
+}
+
+var cacheAssociativityNames enumNames[CacheAssociativity]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v CacheAssociativity) MarshalText() ([]byte, error) {
+	return cacheAssociativityNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *CacheAssociativity) UnmarshalText(text []byte) error {
+	return cacheAssociativityNames.unmarshalText(text, v)
+}
+
+var memoryArrayLocationNames enumNames[MemoryArrayLocation]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryArrayLocation) MarshalText() ([]byte, error) {
+	return memoryArrayLocationNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryArrayLocation) UnmarshalText(text []byte) error {
+	return memoryArrayLocationNames.unmarshalText(text, v)
+}
+
+var memoryArrayUseNames enumNames[MemoryArrayUse]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryArrayUse) MarshalText() ([]byte, error) {
+	return memoryArrayUseNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryArrayUse) UnmarshalText(text []byte) error {
+	return memoryArrayUseNames.unmarshalText(text, v)
+}
+
+var memoryArrayErrorCorrectionNames enumNames[MemoryArrayErrorCorrection]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryArrayErrorCorrection) MarshalText() ([]byte, error) {
+	return memoryArrayErrorCorrectionNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryArrayErrorCorrection) UnmarshalText(text []byte) error {
+	return memoryArrayErrorCorrectionNames.unmarshalText(text, v)
+}
+
+var memoryDeviceFormFactorNames enumNames[MemoryDeviceFormFactor]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryDeviceFormFactor) MarshalText() ([]byte, error) {
+	return memoryDeviceFormFactorNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryDeviceFormFactor) UnmarshalText(text []byte) error {
+	return memoryDeviceFormFactorNames.unmarshalText(text, v)
+}
+
+var memoryDeviceTypeNames enumNames[MemoryDeviceType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryDeviceType) MarshalText() ([]byte, error) {
+	return memoryDeviceTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryDeviceType) UnmarshalText(text []byte) error {
+	return memoryDeviceTypeNames.unmarshalText(text, v)
+}
+
+var memoryDeviceTechnologyNames enumNames[MemoryDeviceTechnology]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v MemoryDeviceTechnology) MarshalText() ([]byte, error) {
+	return memoryDeviceTechnologyNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *MemoryDeviceTechnology) UnmarshalText(text []byte) error {
+	return memoryDeviceTechnologyNames.unmarshalText(text, v)
+}
+
+var bmcInterfaceTypeNames enumNames[BMCInterfaceType]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v BMCInterfaceType) MarshalText() ([]byte, error) {
+	return bmcInterfaceTypeNames.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *BMCInterfaceType) UnmarshalText(text []byte) error {
+	return bmcInterfaceTypeNames.unmarshalText(text, v)
+}
@@ -0,0 +1,74 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ignore
+
+// gen_enum_text generates the MarshalText and UnmarshalText methods of the
+// enum types named on the command line, which encode values by the name their
+// String method gives them, see enumNames.
+//
+//	go run gen_enum_text.go -o enum_text.go Type...
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"unicode"
+)
+
+var output = flag.String("o", "enum_text.go", "output file")
+
+// varName returns the name of the enumNames variable of typ, with its leading
+// upper case word or acronym in lower case.
+func varName(typ string) string {
+	r := []rune(typ)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	// In an acronym followed by a word, the last upper case letter starts the word.
+	if n > 1 && n < len(r) {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r) + "Names"
+}
+
+func main() {
+	flag.Parse()
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `// Code generated by "go run gen_enum_text.go"; DO NOT EDIT.
+
+package dmidecode
+`)
+	for _, typ := range flag.Args() {
+		v := varName(typ)
+		fmt.Fprintf(&b, `
+var %[2]s enumNames[%[1]s]
+
+// MarshalText implements encoding.TextMarshaler.
+func (v %[1]s) MarshalText() ([]byte, error) {
+	return %[2]s.marshalText(v)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *%[1]s) UnmarshalText(text []byte) error {
+	return %[2]s.unmarshalText(text, v)
+}
+`, typ, v)
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/u-root/smbios"
+)
+
+// Handle graph diagnostics.
+var (
+	ErrDuplicateHandle   = errors.New("duplicate handle")
+	ErrDanglingReference = errors.New("dangling handle reference")
+)
+
+// Handles from 0xFF00 are reserved, and used by some fields to tell that
+// there is no referenced structure, such as 0xFFFF for a cache that is not
+// provided or 0xFFFE for memory error information that is not provided.
+const firstReservedHandle = 0xFF00
+
+// Reference is a reference from a field of one structure to another structure.
+type Reference struct {
+	From  uint16 // Handle of the referencing structure.
+	Field string // Name of the referencing field in the typed table.
+	To    uint16 // Referenced handle.
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("0x%04X.%s -> 0x%04X", r.From, r.Field, r.To)
+}
+
+// handleIndex maps handles to the first table with that handle.
+type handleIndex struct {
+	tables   smbios.Tables // Tables the index was built from.
+	byHandle map[uint16]*smbios.Table
+}
+
+func newHandleIndex(tables smbios.Tables) *handleIndex {
+	idx := &handleIndex{tables: tables, byHandle: make(map[uint16]*smbios.Table, len(tables))}
+	for _, t := range tables {
+		if _, ok := idx.byHandle[t.Handle]; !ok {
+			idx.byHandle[t.Handle] = t
+		}
+	}
+	return idx
+}
+
+// indexes reports whether the index was built from tables.
+func (idx *handleIndex) indexes(tables smbios.Tables) bool {
+	return idx != nil && len(idx.tables) == len(tables) && (len(tables) == 0 || &idx.tables[0] == &tables[0])
+}
+
+// Resolve returns the table with the specified handle, or nil if there is
+// none. If several tables share the handle, the first one is returned.
+//
+// Tables are looked up in the index built by ParseInfo, or scanned in order if
+// Info was not created by ParseInfo or Tables has been reassigned since.
+func (i *Info) Resolve(handle uint16) *smbios.Table {
+	if i.handles.indexes(i.Tables) {
+		return i.handles.byHandle[handle]
+	}
+	return i.Tables.TableByHandle(handle)
+}
+
+// HandleGraph is the graph of references between the structures of an Info.
+type HandleGraph struct {
+	refs  map[uint16][]Reference
+	inRef map[uint16][]Reference
+
+	// Diagnostics lists the inconsistencies found while building the graph:
+	// duplicate handles (ErrDuplicateHandle), references to handles that do
+	// not exist (ErrDanglingReference) and tables that could not be parsed.
+	Diagnostics []error
+}
+
+// References returns the references from the structure with the specified
+// handle to other structures, in field order.
+func (g *HandleGraph) References(handle uint16) []Reference {
+	return g.refs[handle]
+}
+
+// ReferencedBy returns the references to the structure with the specified
+// handle, in table order.
+func (g *HandleGraph) ReferencedBy(handle uint16) []Reference {
+	return g.inRef[handle]
+}
+
+// HandleGraph returns the graph of handle references between the tables.
+// Dangling references are part of the graph, and are also reported in the
+// diagnostics.
+func (i *Info) HandleGraph() *HandleGraph {
+	g := &HandleGraph{
+		refs:  make(map[uint16][]Reference),
+		inRef: make(map[uint16][]Reference),
+	}
+	seen := make(map[uint16]bool, len(i.Tables))
+	for _, t := range i.Tables {
+		if seen[t.Handle] {
+			g.Diagnostics = append(g.Diagnostics, fmt.Errorf("%w: 0x%04X (type %d)", ErrDuplicateHandle, t.Handle, t.Type))
+		}
+		seen[t.Handle] = true
+	}
+	for _, t := range i.Tables {
+		refs, err := tableReferences(t)
+		if err != nil {
+			g.Diagnostics = append(g.Diagnostics, fmt.Errorf("handle 0x%04X: %w", t.Handle, err))
+			continue
+		}
+		for _, r := range refs {
+			if !seen[r.To] {
+				g.Diagnostics = append(g.Diagnostics, fmt.Errorf("%w: %s", ErrDanglingReference, r))
+			}
+			g.refs[r.From] = append(g.refs[r.From], r)
+			g.inRef[r.To] = append(g.inRef[r.To], r)
+		}
+	}
+	return g
+}
+
+// tableReferences returns the handle references held by the fields of t that
+// are present, ignoring reserved handles.
+func tableReferences(t *smbios.Table) ([]Reference, error) {
+	var refs []Reference
+	add := func(field string, h uint16) {
+		if h < firstReservedHandle {
+			refs = append(refs, Reference{From: t.Handle, Field: field, To: h})
+		}
+	}
+	switch t.Type {
+	case smbios.TableTypeBaseboardInfo:
+		bi, err := ParseBaseboardInfo(t)
+		if err != nil {
+			return nil, err
+		}
+		if bi.Has("ChassisHandle") {
+			add("ChassisHandle", bi.ChassisHandle)
+		}
+		for _, h := range bi.ObjectHandles {
+			add("ObjectHandles", h)
+		}
+	case smbios.TableTypeProcessorInfo:
+		pi, err := ParseProcessorInfo(t)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range []struct {
+			name string
+			h    uint16
+		}{
+			{"L1CacheHandle", pi.L1CacheHandle},
+			{"L2CacheHandle", pi.L2CacheHandle},
+			{"L3CacheHandle", pi.L3CacheHandle},
+		} {
+			if pi.Has(f.name) {
+				add(f.name, f.h)
+			}
+		}
+	case smbios.TableTypePhysicalMemoryArray:
+		ma, err := ParsePhysicalMemoryArray(t)
+		if err != nil {
+			return nil, err
+		}
+		add("MemoryErrorInfoHandle", ma.MemoryErrorInfoHandle)
+	case smbios.TableTypeMemoryDevice:
+		md, err := ParseMemoryDevice(t)
+		if err != nil {
+			return nil, err
+		}
+		add("PhysicalMemoryArrayHandle", md.PhysicalMemoryArrayHandle)
+		add("MemoryErrorInfoHandle", md.MemoryErrorInfoHandle)
+	case smbios.TableTypeMemoryArrayMappedAddress:
+		mam, err := ParseMemoryArrayMappedAddress(t)
+		if err != nil {
+			return nil, err
+		}
+		add("PhysicalMemoryArrayHandle", mam.PhysicalMemoryArrayHandle)
+	}
+	return refs, nil
+}
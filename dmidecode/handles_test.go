@@ -0,0 +1,162 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func handleTable(typ smbios.TableType, handle uint16, data []byte) *smbios.Table {
+	return &smbios.Table{
+		Header: smbios.Header{Type: typ, Length: uint8(4 + len(data)), Handle: handle},
+		Data:   data,
+	}
+}
+
+func handleTestInfo() *Info {
+	baseboard := make([]byte, 0x0a)
+	baseboard[0x07] = 0x03 // Chassis Handle.
+	baseboard = append(baseboard, 2, 0x04, 0x00, 0x99, 0x00)
+
+	processor := make([]byte, 0x1c)
+	processor[0x16], processor[0x17] = 0x07, 0x00 // L1 Cache Handle.
+	processor[0x18], processor[0x19] = 0xff, 0xff // L2 Cache Handle.
+	processor[0x1a], processor[0x1b] = 0x07, 0x00 // L3 Cache Handle.
+
+	memory := make([]byte, 0x11)
+	memory[0x00], memory[0x01] = 0x10, 0x00 // Physical Memory Array Handle.
+	memory[0x02], memory[0x03] = 0xfe, 0xff // Memory Error Information Handle.
+
+	array := make([]byte, 0x0b)
+	array[0x07], array[0x08] = 0xfe, 0xff // Memory Error Information Handle.
+
+	return &Info{
+		Tables: smbios.Tables{
+			handleTable(smbios.TableTypeBaseboardInfo, 0x02, baseboard),
+			handleTable(smbios.TableTypeChassisInfo, 0x03, nil),
+			handleTable(smbios.TableTypeProcessorInfo, 0x04, processor),
+			handleTable(smbios.TableTypeCacheInfo, 0x07, nil),
+			handleTable(smbios.TableTypePhysicalMemoryArray, 0x10, array),
+			handleTable(smbios.TableTypeMemoryDevice, 0x11, memory),
+			handleTable(smbios.TableTypeProcessorInfo, 0x12, make([]byte, 4)),
+			handleTable(smbios.TableTypeInactive, 0x03, nil),
+		},
+	}
+}
+
+func TestResolve(t *testing.T) {
+	literal := handleTestInfo()
+	indexed := handleTestInfo()
+	indexed.handles = newHandleIndex(indexed.Tables)
+	for _, info := range []*Info{literal, indexed} {
+		for _, tt := range []struct {
+			handle uint16
+			want   *smbios.Table
+		}{
+			{0x02, info.Tables[0]},
+			{0x03, info.Tables[1]}, // First of duplicates.
+			{0x11, info.Tables[5]},
+			{0x99, nil},
+		} {
+			if got := info.Resolve(tt.handle); got != tt.want {
+				t.Errorf("Resolve(0x%04X) = %v, want %v", tt.handle, got, tt.want)
+			}
+		}
+	}
+
+	// The index is not used for tables it was not built from.
+	tables := smbios.Tables{handleTable(smbios.TableTypeChassisInfo, 0x99, nil)}
+	indexed.Tables = tables
+	if got := indexed.Resolve(0x99); got != tables[0] {
+		t.Errorf("Resolve(0x0099) after reassigning Tables = %v, want %v", got, tables[0])
+	}
+	if got := indexed.Resolve(0x02); got != nil {
+		t.Errorf("Resolve(0x0002) after reassigning Tables = %v, want nil", got)
+	}
+}
+
+func TestHandleGraph(t *testing.T) {
+	g := handleTestInfo().HandleGraph()
+
+	for _, tt := range []struct {
+		name string
+		got  []Reference
+		want []Reference
+	}{
+		{
+			name: "References(baseboard)",
+			got:  g.References(0x02),
+			want: []Reference{
+				{From: 0x02, Field: "ChassisHandle", To: 0x03},
+				{From: 0x02, Field: "ObjectHandles", To: 0x04},
+				{From: 0x02, Field: "ObjectHandles", To: 0x99},
+			},
+		},
+		{
+			name: "References(processor)",
+			got:  g.References(0x04),
+			want: []Reference{
+				{From: 0x04, Field: "L1CacheHandle", To: 0x07},
+				{From: 0x04, Field: "L3CacheHandle", To: 0x07},
+			},
+		},
+		{
+			name: "References(memory device)",
+			got:  g.References(0x11),
+			want: []Reference{
+				{From: 0x11, Field: "PhysicalMemoryArrayHandle", To: 0x10},
+			},
+		},
+		{
+			name: "References(cache)",
+			got:  g.References(0x07),
+		},
+		{
+			name: "ReferencedBy(cache)",
+			got:  g.ReferencedBy(0x07),
+			want: []Reference{
+				{From: 0x04, Field: "L1CacheHandle", To: 0x07},
+				{From: 0x04, Field: "L3CacheHandle", To: 0x07},
+			},
+		},
+		{
+			name: "ReferencedBy(processor)",
+			got:  g.ReferencedBy(0x04),
+			want: []Reference{
+				{From: 0x02, Field: "ObjectHandles", To: 0x04},
+			},
+		},
+		{
+			name: "ReferencedBy(reserved)",
+			got:  g.ReferencedBy(0xffff),
+		},
+	} {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	wantDiags := []error{ErrDuplicateHandle, ErrDanglingReference, io.ErrUnexpectedEOF}
+	if len(g.Diagnostics) != len(wantDiags) {
+		t.Fatalf("Diagnostics = %v, want %d diagnostics", g.Diagnostics, len(wantDiags))
+	}
+	for i, want := range wantDiags {
+		if !errors.Is(g.Diagnostics[i], want) {
+			t.Errorf("Diagnostics[%d] = %v, want %v", i, g.Diagnostics[i], want)
+		}
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	r := Reference{From: 0x02, Field: "ChassisHandle", To: 0x03}
+	if got, want := r.String(), "0x0002.ChassisHandle -> 0x0003"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -30,9 +30,15 @@ const (
 )
 
 // Info contains the SMBIOS information.
+//
+// Info returned by ParseInfo and Load indexes its tables by handle. The index
+// is not used once Tables is reassigned, but the tables must not be modified
+// in place.
 type Info struct {
 	Entry  smbios.EntryPoint
 	Tables smbios.Tables
+
+	handles *handleIndex
 }
 
 // String returns a summary of the SMBIOS version and number of tables.
@@ -40,7 +46,8 @@ func (i *Info) String() string {
 	return fmt.Sprintf("%s (%d tables)", i.Entry, len(i.Tables))
 }
 
-// ParseInfo parses SMBIOS information from binary data.
+// ParseInfo parses SMBIOS information from binary data. The tables are given
+// the SMBIOS version of the entry point, which decides the fields they define.
 func ParseInfo(entryData, tableData []byte) (*Info, error) {
 	entry, err := smbios.ParseEntry(bytes.NewReader(entryData))
 	if err != nil {
@@ -50,13 +57,32 @@ func ParseInfo(entryData, tableData []byte) (*Info, error) {
 	if err != nil {
 		return nil, err
 	}
+	v := smbios.EntryVersion(entry)
+	for _, t := range tables {
+		t.Version = v
+	}
 
 	return &Info{
-		Tables: tables,
-		Entry:  entry,
+		Tables:  tables,
+		Entry:   entry,
+		handles: newHandleIndex(tables),
 	}, nil
 }
 
+// Load parses SMBIOS info from the first of srcs that can be read, and
+// returns the source that was used.
+func Load(srcs ...smbios.Source) (*Info, smbios.Source, error) {
+	entryData, tableData, src, err := smbios.ReadFirst(srcs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := ParseInfo(entryData, tableData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", src, err)
+	}
+	return info, src, nil
+}
+
 // GetBIOSInfo returns the Bios Info (type 0) table, if present.
 func (i *Info) GetBIOSInfo() (*BIOSInfo, error) {
 	t := i.Tables.TableByType(smbios.TableTypeBIOSInfo)
@@ -142,6 +168,19 @@ func (i *Info) GetSystemSlots() ([]*SystemSlots, error) {
 	return res, nil
 }
 
+// GetPhysicalMemoryArrays returns all the Physical Memory Array (type 16) tables present.
+func (i *Info) GetPhysicalMemoryArrays() ([]*PhysicalMemoryArray, error) {
+	var res []*PhysicalMemoryArray
+	for _, t := range i.Tables.TablesByType(smbios.TableTypePhysicalMemoryArray) {
+		ma, err := ParsePhysicalMemoryArray(t)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ma)
+	}
+	return res, nil
+}
+
 // GetMemoryDevices returns all the Memory Device (type 17) tables present.
 func (i *Info) GetMemoryDevices() ([]*MemoryDevice, error) {
 	var res []*MemoryDevice
@@ -155,6 +194,19 @@ func (i *Info) GetMemoryDevices() ([]*MemoryDevice, error) {
 	return res, nil
 }
 
+// GetMemoryArrayMappedAddresses returns all the Memory Array Mapped Address (type 19) tables present.
+func (i *Info) GetMemoryArrayMappedAddresses() ([]*MemoryArrayMappedAddress, error) {
+	var res []*MemoryArrayMappedAddress
+	for _, t := range i.Tables.TablesByType(smbios.TableTypeMemoryArrayMappedAddress) {
+		mam, err := ParseMemoryArrayMappedAddress(t)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mam)
+	}
+	return res, nil
+}
+
 // GetIPMIDeviceInfo returns all the IPMI Device Info (type 38) tables present.
 func (i *Info) GetIPMIDeviceInfo() ([]*IPMIDeviceInfo, error) {
 	var res []*IPMIDeviceInfo
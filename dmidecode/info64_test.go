@@ -5,10 +5,13 @@
 package dmidecode
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+
+	"github.com/u-root/smbios"
 )
 
 func Test64ParseInfoHeaderMalformed(t *testing.T) {
@@ -142,3 +145,30 @@ func TestKmgt(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad(t *testing.T) {
+	data, err := os.ReadFile("./testdata/smbios_table.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := smbios.SysfsSource{Path: "./testdata/NOT_FOUND"}
+	bs := smbios.BytesSource{Entry: data[:32], Table: data[32:]}
+
+	info, src, err := Load(missing, bs)
+	if err != nil {
+		t.Fatalf("Load() = %v", err)
+	}
+	if _, ok := src.(smbios.BytesSource); !ok {
+		t.Errorf("Load() source = %v, want %v", src, bs)
+	}
+	if len(info.Tables) == 0 {
+		t.Errorf("Load() returned no tables")
+	}
+
+	if _, _, err := Load(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load() = %v, want %v", err, os.ErrNotExist)
+	}
+	if _, _, err := Load(smbios.BytesSource{Entry: data[:10]}); err == nil {
+		t.Errorf("Load() with malformed entry point = nil, want error")
+	}
+}
@@ -5,24 +5,17 @@
 package dmidecode
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
+	"github.com/u-root/smbios"
 )
 
 // FromSysfs parses SMBIOS info from sysfs tables.
 func FromSysfs() (*Info, error) {
-	return fromSysfs("/sys/firmware/dmi/tables")
+	info, _, err := Load(smbios.SysfsSource{Path: smbios.DefaultSysfsPath})
+	return info, err
 }
 
-func fromSysfs(sysfsPath string) (*Info, error) {
-	entry, err := os.ReadFile(filepath.Join(sysfsPath, "smbios_entry_point"))
-	if err != nil {
-		return nil, fmt.Errorf("error reading SMBIOS entry data: %v", err)
-	}
-	data, err := os.ReadFile(filepath.Join(sysfsPath, "DMI"))
-	if err != nil {
-		return nil, fmt.Errorf("error reading DMI data: %v", err)
-	}
-	return ParseInfo(entry, data)
+// FromDefaultSources parses SMBIOS info from the first of
+// smbios.DefaultSources that can be read, and returns the source used.
+func FromDefaultSources() (*Info, smbios.Source, error) {
+	return Load(smbios.DefaultSources()...)
 }
@@ -0,0 +1,513 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/u-root/smbios"
+)
+
+// JSONSchemaVersion is the version of the JSON encoding of decoded tables.
+//
+// All decoded table types can be marshaled with encoding/json and unmarshaled
+// back into the same struct. Version 1 of the encoding follows these rules:
+//
+//   - Objects use the Go field names of the table struct as keys, in struct order.
+//   - The SMBIOS header is nested as "Header": {"Type", "Length", "Handle"}.
+//   - Integer fields without a defined meaning per value are JSON numbers.
+//   - Enumerations (ChassisType, ProcessorFamily, MemoryDeviceType, ...) are
+//     strings holding the same name as their String method. Values without a
+//     name, or whose name is shared with a lower value, are "0x"-prefixed hex.
+//   - Bitfields (BIOSChars, ProcessorCharacteristics, ...) are arrays of the
+//     names of the bits that are set, in bit order. Bits without a unique name
+//     are given as "0x"-prefixed hex of the bit value.
+//   - Processor IDs are "0x"-prefixed strings of 16 hex digits, as they
+//     exceed the integers that JSON numbers represent exactly.
+//   - UUIDs are strings in canonical 8-4-4-4-12 form, in the byte order of
+//     DSP0134 7.2.1. The system UUID of SMBIOS versions before 2.6 is given
+//     with all bytes in table order, as SystemInfo.UUIDString gives it.
+//   - Fields the table does not contain, those for which the Has method of the
+//     table type reports false, are omitted by MarshalTableJSON. When
+//     unmarshaled they keep the value the parser gives absent fields: zero, or
+//     the field's default.
+//
+// Tables that are not decoded use the same wrapper with "Raw": true, see
+// MarshalRawTableJSON.
+//
+// The version is incremented when a change to these rules or to a table struct
+// would cause previously produced JSON to be decoded differently. See
+// JSONSchema for a machine-readable description of each table type.
+const JSONSchemaVersion = 1
+
+// ErrUnsupportedSchemaVersion is returned when unmarshaling JSON produced with a different schema version.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported JSON schema version")
+
+// newTypedTable returns a pointer to an empty struct for decoding tables of type tt.
+func newTypedTable(tt smbios.TableType) (fmt.Stringer, error) {
+	switch tt {
+	case smbios.TableTypeBIOSInfo:
+		return &BIOSInfo{}, nil
+	case smbios.TableTypeSystemInfo:
+		return &SystemInfo{}, nil
+	case smbios.TableTypeBaseboardInfo:
+		return &BaseboardInfo{}, nil
+	case smbios.TableTypeChassisInfo:
+		return &ChassisInfo{}, nil
+	case smbios.TableTypeProcessorInfo:
+		return &ProcessorInfo{}, nil
+	case smbios.TableTypeCacheInfo:
+		return &CacheInfo{}, nil
+	case smbios.TableTypeSystemSlots:
+		return &SystemSlots{}, nil
+	case smbios.TableTypePhysicalMemoryArray:
+		return &PhysicalMemoryArray{}, nil
+	case smbios.TableTypeMemoryDevice:
+		return &MemoryDevice{}, nil
+	case smbios.TableTypeMemoryArrayMappedAddress:
+		return &MemoryArrayMappedAddress{}, nil
+	case smbios.TableTypeIPMIDeviceInfo:
+		return &IPMIDeviceInfo{}, nil
+	case smbios.TableTypeTPMDevice:
+		return &TPMDevice{}, nil
+	case smbios.TableTypeInactive:
+		return &InactiveTable{}, nil
+	case smbios.TableTypeEndOfTable:
+		return &EndOfTable{}, nil
+	}
+	return nil, ErrUnsupportedTableType
+}
+
+type tableJSON struct {
+	SchemaVersion int
+	Type          smbios.TableType
+	SMBIOSVersion *smbios.Version `json:",omitempty"`
+	Raw           bool            `json:",omitempty"`
+	Error         string          `json:",omitempty"`
+	Table         json.RawMessage
+}
+
+// rawTableJSON is the encoding of a table that is not decoded.
+type rawTableJSON struct {
+	Header  smbios.Header
+	Data    string // Hex of the structured part.
+	Strings []string
+}
+
+// versionedTable is implemented by decoded tables that embed tableVersion.
+type versionedTable interface {
+	specVersion() smbios.Version
+	setSpecVersion(v smbios.Version)
+}
+
+// MarshalTableJSON marshals a table returned by ParseTypedTable, wrapped in an
+// object that records the schema version, the table type and, if known, the
+// SMBIOS version the table was decoded for:
+//
+//	{"SchemaVersion": 1, "Type": 17, "SMBIOSVersion": "3.2", "Table": {...}}
+//
+// Fields that the table does not contain are omitted.
+func MarshalTableJSON(t fmt.Stringer) ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(t))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedTableType, t)
+	}
+	var h smbios.Header
+	if f := v.FieldByName("Header"); f.IsValid() {
+		h = f.Interface().(smbios.Header)
+	}
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = omitAbsentFields(t, data); err != nil {
+		return nil, err
+	}
+	tj := tableJSON{SchemaVersion: JSONSchemaVersion, Type: h.Type, Table: data}
+	if vt, ok := t.(versionedTable); ok && !vt.specVersion().IsZero() {
+		v := vt.specVersion()
+		tj.SMBIOSVersion = &v
+	}
+	return json.Marshal(tj)
+}
+
+// MarshalRawTableJSON marshals a table that is not decoded, in the same
+// wrapper as MarshalTableJSON. The table is given by its header, the hex of
+// its structured part and its strings:
+//
+//	{"SchemaVersion": 1, "Type": 131, "Raw": true, "Table": {"Header": {...}, "Data": "3100...", "Strings": []}}
+//
+// If decodeErr is not nil, it is the reason the table is not decoded and is
+// recorded as "Error".
+func MarshalRawTableJSON(t *smbios.Table, decodeErr error) ([]byte, error) {
+	rt := rawTableJSON{Header: t.Header, Data: hex.EncodeToString(t.Data), Strings: t.Strings}
+	if rt.Strings == nil {
+		rt.Strings = []string{}
+	}
+	data, err := json.Marshal(rt)
+	if err != nil {
+		return nil, err
+	}
+	tj := tableJSON{SchemaVersion: JSONSchemaVersion, Type: t.Type, Raw: true, Table: data}
+	if !t.Version.IsZero() {
+		tj.SMBIOSVersion = &t.Version
+	}
+	if decodeErr != nil {
+		tj.Error = decodeErr.Error()
+	}
+	return json.Marshal(tj)
+}
+
+// UnmarshalTableJSON unmarshals a table previously marshaled with MarshalTableJSON
+// into the same type that ParseTypedTable returns for it. Tables marshaled
+// with MarshalRawTableJSON are returned as *smbios.Table.
+func UnmarshalTableJSON(data []byte) (fmt.Stringer, error) {
+	var tj tableJSON
+	if err := json.Unmarshal(data, &tj); err != nil {
+		return nil, err
+	}
+	if tj.SchemaVersion != JSONSchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, tj.SchemaVersion)
+	}
+	if tj.Raw {
+		return unmarshalRawTable(tj)
+	}
+	t, err := newTypedTable(tj.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := setFieldDefaults(t); err != nil {
+		return nil, err
+	}
+	// The version is set first, the encoding of some fields depends on it.
+	if vt, ok := t.(versionedTable); ok && tj.SMBIOSVersion != nil {
+		vt.setSpecVersion(*tj.SMBIOSVersion)
+	}
+	if err := json.Unmarshal(tj.Table, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// fieldChecker is implemented by decoded tables that report which fields
+// they contain.
+type fieldChecker interface {
+	Has(field string) bool
+}
+
+// omitAbsentFields removes the fields of t that t does not contain from
+// data, the JSON object of t, keeping the remaining keys in order.
+func omitAbsentFields(t fmt.Stringer, data []byte) ([]byte, error) {
+	fc, ok := t.(fieldChecker)
+	if !ok {
+		return data, nil
+	}
+	absent := map[string]bool{}
+	v := reflect.Indirect(reflect.ValueOf(t))
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, err := fieldTags(f)
+		if err != nil {
+			return nil, err
+		}
+		// The header is not a field of the table data.
+		if tag.ignore && tag.skip == 0 {
+			continue
+		}
+		if !fc.Has(f.Name) {
+			name := f.Name
+			if jt, ok := f.Tag.Lookup("json"); ok {
+				name = strings.Split(jt, ",")[0]
+			}
+			absent[name] = true
+		}
+	}
+	if len(absent) == 0 {
+		return data, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return nil, err
+	} else if tok != json.Delim('{') {
+		return nil, fmt.Errorf("%T is not encoded as a JSON object", t)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := tok.(string)
+		var val json.RawMessage
+		if err := dec.Decode(&val); err != nil {
+			return nil, err
+		}
+		if absent[key] {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// setFieldDefaults sets the fields of t that have a default to that default,
+// as the parser does for fields beyond the end of the table.
+func setFieldDefaults(t fmt.Stringer) error {
+	v := reflect.Indirect(reflect.ValueOf(t))
+	for i := 0; i < v.NumField(); i++ {
+		tag, err := fieldTags(v.Type().Field(i))
+		if err != nil {
+			return err
+		}
+		if tag.def != 0 {
+			v.Field(i).SetUint(tag.def)
+		}
+	}
+	return nil
+}
+
+func unmarshalRawTable(tj tableJSON) (*smbios.Table, error) {
+	var rt rawTableJSON
+	if err := json.Unmarshal(tj.Table, &rt); err != nil {
+		return nil, err
+	}
+	d, err := hex.DecodeString(rt.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid table data: %w", err)
+	}
+	t := &smbios.Table{Header: rt.Header, Data: d, Strings: rt.Strings}
+	if tj.SMBIOSVersion != nil {
+		t.Version = *tj.SMBIOSVersion
+	}
+	return t, nil
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) describing the JSON encoding
+// of the decoded table of type tt.
+func JSONSchema(tt smbios.TableType) ([]byte, error) {
+	t, err := newTypedTable(tt)
+	if err != nil {
+		return nil, err
+	}
+	s := typeSchema(reflect.TypeOf(t).Elem())
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	s["title"] = fmt.Sprintf("%s (DMI type %d), schema version %d", tt, uint8(tt), JSONSchemaVersion)
+	return json.MarshalIndent(s, "", "  ")
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func typeSchema(t reflect.Type) map[string]interface{} {
+	switch {
+	case t.Implements(jsonMarshalerType):
+		// Only bitfields implement json.Marshaler.
+		return map[string]interface{}{
+			"description": t.Name() + " flag names",
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+		}
+	case t.Implements(textMarshalerType):
+		return map[string]interface{}{
+			"description": t.Name(),
+			"type":        "string",
+		}
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		props := map[string]interface{}{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name := f.Name
+			tag, hasTag := f.Tag.Lookup("json")
+			if hasTag {
+				name = strings.Split(tag, ",")[0]
+			}
+			if name == "-" {
+				continue
+			}
+			if f.Anonymous && !hasTag {
+				// Promoted fields.
+				for k, v := range typeSchema(f.Type)["properties"].(map[string]interface{}) {
+					props[k] = v
+				}
+				continue
+			}
+			props[name] = typeSchema(f.Type)
+		}
+		return map[string]interface{}{
+			"type":       "object",
+			"properties": props,
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{
+			"type":  []string{"array", "null"},
+			"items": typeSchema(t.Elem()),
+		}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{
+			"type":    "integer",
+			"minimum": 0,
+			"maximum": uint64(1)<<t.Bits() - 1,
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// The text methods of the enum types are generated, see gen_enum_text.go.
+//
+//go:generate go run gen_enum_text.go -o enum_text.go WakeupType BoardType ChassisType ChassisState ChassisSecurityStatus ChassisElementType ChassisRackType ProcessorType ProcessorFamily ProcessorStatus ProcessorUpgrade CacheOperationalMode CacheLocation CacheErrorCorrectionType CacheSystemType CacheAssociativity MemoryArrayLocation MemoryArrayUse MemoryArrayErrorCorrection MemoryDeviceFormFactor MemoryDeviceType MemoryDeviceTechnology BMCInterfaceType
+
+// enumType is a named integer type with a name per value.
+type enumType interface {
+	~uint8 | ~uint16
+	fmt.Stringer
+}
+
+// enumNames resolves the String names of an enum type back into values.
+type enumNames[T enumType] struct {
+	once   sync.Once
+	byName map[string]T
+}
+
+func (e *enumNames[T]) lookup(s string) (T, bool) {
+	e.once.Do(func() {
+		var zero T
+		max := uint64(1)<<reflect.TypeOf(zero).Bits() - 1
+		e.byName = map[string]T{}
+		for i := uint64(0); i <= max; i++ {
+			v := T(i)
+			// Several values may share a name, the lowest one owns it.
+			if _, ok := e.byName[v.String()]; !ok {
+				e.byName[v.String()] = v
+			}
+		}
+	})
+	v, ok := e.byName[s]
+	return v, ok
+}
+
+func (e *enumNames[T]) marshalText(v T) ([]byte, error) {
+	if s := v.String(); s != "" {
+		if u, ok := e.lookup(s); ok && u == v {
+			return []byte(s), nil
+		}
+	}
+	return []byte(fmt.Sprintf("%#x", uint64(v))), nil
+}
+
+func (e *enumNames[T]) unmarshalText(text []byte, v *T) error {
+	if u, ok := e.lookup(string(text)); ok {
+		*v = u
+		return nil
+	}
+	n, err := strconv.ParseUint(string(text), 0, reflect.TypeOf(*v).Bits())
+	if err != nil {
+		return fmt.Errorf("invalid %T %q", *v, text)
+	}
+	*v = T(n)
+	return nil
+}
+
+// flagType is a named integer type in which each bit is a flag.
+type flagType interface {
+	~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// setFlags returns the bits set in v, in bit order.
+func setFlags[T flagType](v T) []T {
+	var res []T
+	for bit := 0; bit < reflect.TypeOf(v).Bits(); bit++ {
+		if f := T(1) << bit; v&f != 0 {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// flagsByName returns the bits of names that have a unique name.
+func flagsByName[T flagType](names map[T]string) map[string]T {
+	byName := map[string]T{}
+	seen := map[string]bool{}
+	for f, n := range names {
+		if n == "" || seen[n] {
+			delete(byName, n)
+			continue
+		}
+		seen[n] = true
+		byName[n] = f
+	}
+	return byName
+}
+
+func marshalFlags[T flagType](v T, names map[T]string) ([]byte, error) {
+	byName := flagsByName(names)
+	res := []string{}
+	for bit := 0; bit < reflect.TypeOf(v).Bits(); bit++ {
+		f := T(1) << bit
+		if v&f == 0 {
+			continue
+		}
+		if n := names[f]; n != "" && byName[n] == f {
+			res = append(res, n)
+		} else {
+			res = append(res, fmt.Sprintf("%#x", uint64(f)))
+		}
+	}
+	return json.Marshal(res)
+}
+
+func unmarshalFlags[T flagType](data []byte, v *T, names map[T]string) error {
+	var ss []string
+	if err := json.Unmarshal(data, &ss); err != nil {
+		return err
+	}
+	byName := flagsByName(names)
+	var res T
+	for _, s := range ss {
+		if f, ok := byName[s]; ok {
+			res |= f
+			continue
+		}
+		n, err := strconv.ParseUint(s, 0, reflect.TypeOf(res).Bits())
+		if err != nil {
+			return fmt.Errorf("invalid %T flag %q", res, s)
+		}
+		res |= T(n)
+	}
+	*v = res
+	return nil
+}
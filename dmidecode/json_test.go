@@ -0,0 +1,299 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	info, err := setupMockData()
+	if err != nil {
+		t.Fatalf("error parsing info data: %v", err)
+	}
+	for _, tbl := range info.Tables {
+		pt, err := ParseTypedTable(tbl)
+		if errors.Is(err, ErrUnsupportedTableType) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseTypedTable(%v) = %v", tbl.Header, err)
+		}
+		data, err := MarshalTableJSON(pt)
+		if err != nil {
+			t.Fatalf("MarshalTableJSON(%v) = %v", tbl.Header, err)
+		}
+		got, err := UnmarshalTableJSON(data)
+		if err != nil {
+			t.Fatalf("UnmarshalTableJSON(%s) = %v", data, err)
+		}
+		if !reflect.DeepEqual(got, pt) {
+			t.Errorf("JSON round trip of %s\ngot  %+v\nwant %+v", data, got, pt)
+		}
+	}
+}
+
+func TestRawTableJSON(t *testing.T) {
+	tbl := &smbios.Table{
+		Header:  smbios.Header{Type: 131, Length: 8, Handle: 30},
+		Data:    []byte{0x31, 0, 0, 0},
+		Strings: []string{"vPro"},
+		Version: smbios.Version{Major: 2, Minor: 8},
+	}
+	data, err := MarshalRawTableJSON(tbl, errors.New("bad table"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"SchemaVersion":1,"Type":131,"SMBIOSVersion":"2.8","Raw":true,"Error":"bad table","Table":{"Header":{"Type":131,"Length":8,"Handle":30},"Data":"31000000","Strings":["vPro"]}}`
+	if string(data) != want {
+		t.Errorf("MarshalRawTableJSON() =\n%s\nwant\n%s", data, want)
+	}
+	got, err := UnmarshalTableJSON(data)
+	if err != nil {
+		t.Fatalf("UnmarshalTableJSON(%s) = %v", data, err)
+	}
+	if !reflect.DeepEqual(got, tbl) {
+		t.Errorf("UnmarshalTableJSON(%s) = %+v, want %+v", data, got, tbl)
+	}
+}
+
+func TestJSONEncoding(t *testing.T) {
+	ci := &ChassisInfo{
+		Header:         smbios.Header{Type: smbios.TableTypeChassisInfo, Length: 0x15, Handle: 3},
+		Type:           ChassisTypeNotebook,
+		BootupState:    ChassisStateSafe,
+		SecurityStatus: ChassisSecurityStatus(0x42),
+	}
+	data, err := json.Marshal(ci)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Header":{"Type":3,"Length":21,"Handle":3},"Manufacturer":"","Type":"Notebook","Version":"","SerialNumber":"","AssetTagNumber":"","BootupState":"Safe","PowerSupplyState":"0x0","ThermalState":"0x0","SecurityStatus":"0x42","OEMInfo":0,"Height":0,"NumberOfPowerCords":0,"ContainedElements":null,"SKUNumber":"","RackType":"Unspecified","RackHeight":0}`
+	if string(data) != want {
+		t.Errorf("json.Marshal(ChassisInfo) =\n%s\nwant\n%s", data, want)
+	}
+
+	bi := &BIOSInfo{
+		Characteristics:     BIOSCharsReserved | BIOSCharsReserved2 | BIOSCharsPCI | 1<<40,
+		CharacteristicsExt2: BIOSCharsExt2UEFISpecification,
+	}
+	data, err = json.Marshal(bi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Characteristics     []string
+		CharacteristicsExt1 []string
+		CharacteristicsExt2 []string
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"0x1", "0x2", "PCI is supported", "0x10000000000"}; !reflect.DeepEqual(got.Characteristics, want) {
+		t.Errorf("Characteristics = %q, want %q", got.Characteristics, want)
+	}
+	if want := []string{}; !reflect.DeepEqual(got.CharacteristicsExt1, want) {
+		t.Errorf("CharacteristicsExt1 = %q, want %q", got.CharacteristicsExt1, want)
+	}
+	if want := []string{"UEFI is supported"}; !reflect.DeepEqual(got.CharacteristicsExt2, want) {
+		t.Errorf("CharacteristicsExt2 = %q, want %q", got.CharacteristicsExt2, want)
+	}
+}
+
+func TestMarshalTableJSONAbsentFields(t *testing.T) {
+	ci := &ChassisInfo{
+		Header: smbios.Header{Type: smbios.TableTypeChassisInfo, Length: 0x15, Handle: 3},
+		Type:   ChassisTypeNotebook,
+	}
+	data, err := MarshalTableJSON(ci)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Table map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"Header", "Type", "NumberOfPowerCords", "ContainedElements"} {
+		if _, ok := got.Table[f]; !ok {
+			t.Errorf("MarshalTableJSON(ChassisInfo) = %s, want field %s", data, f)
+		}
+	}
+	for _, f := range []string{"SKUNumber", "RackType", "RackHeight"} {
+		if _, ok := got.Table[f]; ok {
+			t.Errorf("MarshalTableJSON(ChassisInfo) = %s, want no field %s", data, f)
+		}
+	}
+
+	// Absent fields with a default decode to the default.
+	bi, err := ParseBIOSInfo(&smbios.Table{
+		Header: smbios.Header{Type: smbios.TableTypeBIOSInfo, Length: 0x12},
+		Data:   []byte{1, 2, 0, 3, 0x10, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = MarshalTableJSON(bi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Table["BIOSMajor"]; ok {
+		t.Errorf("MarshalTableJSON(BIOSInfo) = %s, want no field BIOSMajor", data)
+	}
+	u, err := UnmarshalTableJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(u, bi) {
+		t.Errorf("UnmarshalTableJSON(%s) = %+v, want %+v", data, u, bi)
+	}
+}
+
+func TestSystemInfoJSONUUID(t *testing.T) {
+	for _, tt := range []struct {
+		version smbios.Version
+		want    string
+	}{
+		{smbios.Version{Major: 2, Minor: 4}, "00010203-0405-0607-0809-0a0b0c0d0e0f"},
+		{smbios.Version{Major: 2, Minor: 6}, "03020100-0504-0706-0809-0a0b0c0d0e0f"},
+	} {
+		si := &SystemInfo{
+			Header:       smbios.Header{Type: smbios.TableTypeSystemInfo, Length: 27},
+			tableVersion: tableVersion{tt.version},
+			UUID:         UUID{0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf},
+		}
+		if got := si.UUIDString(); got != tt.want {
+			t.Errorf("SMBIOS %s: UUIDString() = %s, want %s", tt.version, got, tt.want)
+		}
+		data, err := MarshalTableJSON(si)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got struct {
+			Table struct{ UUID string }
+		}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Table.UUID != tt.want {
+			t.Errorf("SMBIOS %s: MarshalTableJSON() UUID = %s, want %s", tt.version, got.Table.UUID, tt.want)
+		}
+		u, err := UnmarshalTableJSON(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(u, si) {
+			t.Errorf("SMBIOS %s: UnmarshalTableJSON(%s) = %+v, want %+v", tt.version, data, u, si)
+		}
+	}
+}
+
+func TestEnumText(t *testing.T) {
+	for _, tt := range []struct {
+		v    interface{ MarshalText() ([]byte, error) }
+		want string
+	}{
+		{ProcessorFamilyCoreI7, "Core i7"},
+		{ProcessorFamily(0x1234), "0x1234"},
+		{ProcessorFamilyByte(0xc6), "Core i7"},
+		{ProcessorID(0xbfebfbff000806ea), "0xbfebfbff000806ea"},
+		{ProcessorID(0x806ea), "0x00000000000806ea"},
+		{ProcessorStatus(0x41), "Populated, Enabled"},
+		{ProcessorStatus(0x49), "0x49"},
+		{ChassisTypeNotebook | 0x80, "0x8a"},
+		{ChassisElementType(0x80 | 17), "Memory Device"},
+		{MemoryDeviceTypeDDR4, "DDR4"},
+		{UUID{0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf}, "03020100-0504-0706-0809-0a0b0c0d0e0f"},
+		{UUID{}, "00000000-0000-0000-0000-000000000000"},
+		{TPMDeviceVendorID{0, 'X', 'F', 'I'}, "\x00XFI"},
+		{TPMDeviceVendorID{'A', 'M', 'D', 0}, "AMD"},
+		{TPMDeviceVendorID{0xff, 1, 2, 3}, "0xff010203"},
+	} {
+		got, err := tt.v.MarshalText()
+		if err != nil {
+			t.Fatalf("%T(%v).MarshalText() = %v", tt.v, tt.v, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%T(%v).MarshalText() = %q, want %q", tt.v, tt.v, got, tt.want)
+		}
+		// Must decode back to the same value.
+		p := reflect.New(reflect.TypeOf(tt.v))
+		if err := p.Interface().(interface{ UnmarshalText([]byte) error }).UnmarshalText(got); err != nil {
+			t.Fatalf("%T.UnmarshalText(%q) = %v", tt.v, got, err)
+		}
+		if u := p.Elem().Interface(); u != tt.v {
+			t.Errorf("%T.UnmarshalText(%q) = %v, want %v", tt.v, got, u, tt.v)
+		}
+	}
+}
+
+func TestEnumTextInvalid(t *testing.T) {
+	var mt MemoryDeviceType
+	if err := mt.UnmarshalText([]byte("DDR9")); err == nil {
+		t.Errorf("UnmarshalText(DDR9) = nil, want error")
+	}
+	if err := mt.UnmarshalText([]byte("0x100")); err == nil {
+		t.Errorf("UnmarshalText(0x100) = nil, want error")
+	}
+	var u UUID
+	if err := u.UnmarshalText([]byte("03020100-0504-0706-0809-0a0b0c0d0e0g")); err == nil {
+		t.Errorf("UUID.UnmarshalText() = nil, want error")
+	}
+	var c ProcessorCharacteristics
+	if err := json.Unmarshal([]byte(`["64-bit capable", "Flying"]`), &c); err == nil {
+		t.Errorf("ProcessorCharacteristics.UnmarshalJSON() = nil, want error")
+	}
+}
+
+func TestUnmarshalTableJSONVersion(t *testing.T) {
+	_, err := UnmarshalTableJSON([]byte(`{"SchemaVersion": 0, "Type": 1, "Table": {}}`))
+	if !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("UnmarshalTableJSON() = %v, want %v", err, ErrUnsupportedSchemaVersion)
+	}
+	_, err = UnmarshalTableJSON([]byte(`{"SchemaVersion": 1, "Type": 200, "Table": {}}`))
+	if !errors.Is(err, ErrUnsupportedTableType) {
+		t.Errorf("UnmarshalTableJSON() = %v, want %v", err, ErrUnsupportedTableType)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema(smbios.TableTypeMemoryDevice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s struct {
+		Type       string `json:"type"`
+		Properties map[string]struct {
+			Type interface{} `json:"type"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	for field, want := range map[string]interface{}{
+		"Header":       "object",
+		"Type":         "string",
+		"TypeDetail":   "array",
+		"Size":         "integer",
+		"PartNumber":   "string",
+		"ExtendedSize": "integer",
+	} {
+		if got := s.Properties[field].Type; got != want {
+			t.Errorf("schema of MemoryDevice.%s has type %v, want %v", field, got, want)
+		}
+	}
+	if _, err := JSONSchema(smbios.TableType(200)); !errors.Is(err, ErrUnsupportedTableType) {
+		t.Errorf("JSONSchema(200) = %v, want %v", err, ErrUnsupportedTableType)
+	}
+}
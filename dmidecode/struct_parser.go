@@ -27,7 +27,6 @@ var (
 )
 
 func parseStruct(t *smbios.Table, off int, complete bool, sp interface{}) (int, error) {
-	var err error
 	var ok bool
 	var sv reflect.Value
 	if sv, ok = sp.(reflect.Value); !ok {
@@ -40,21 +39,19 @@ func parseStruct(t *smbios.Table, off int, complete bool, sp interface{}) (int,
 		f := sv.Type().Field(i)
 		fv := sv.Field(i)
 		ft := fv.Type()
-		tags := f.Tag.Get(fieldTagKey)
-		// fmt.Printf("XX %02Xh f %s t %s k %s %s\n", off, f.Name, f.Type.Name(), fv.Kind(), tags)
+		// fmt.Printf("XX %02Xh f %s t %s k %s %s\n", off, f.Name, f.Type.Name(), fv.Kind(), f.Tag)
 		// Check tags first
-		ignore := false
-		for _, tag := range strings.Split(tags, ",") {
-			tp := strings.Split(tag, "=")
-			switch tp[0] {
-			case "-":
-				ignore = true
-			case "skip":
-				numBytes, _ := strconv.Atoi(tp[1])
-				off += numBytes
-			}
+		tag, err := fieldTags(f)
+		if err != nil {
+			return off, fmt.Errorf("%s.%s: %w", svtn, f.Name, err)
+		}
+		// Fields introduced after the version of the table are absent,
+		// just like fields beyond its length.
+		if !t.Version.Supports(tag.since) {
+			break
 		}
-		if ignore {
+		off += tag.skip
+		if tag.ignore {
 			continue
 		}
 		var verr error
@@ -115,29 +112,18 @@ func parseStruct(t *smbios.Table, off int, complete bool, sp interface{}) (int,
 		f := sv.Type().Field(i)
 		fv := sv.Field(i)
 		ft := fv.Type()
-		tags := f.Tag.Get(fieldTagKey)
-		// fmt.Printf("XX %02Xh f %s t %s k %s %s\n", off, f.Name, f.Type.Name(), fv.Kind(), tags)
 		// Check tags first
-		ignore := false
-		var defValue uint64
-		for _, tag := range strings.Split(tags, ",") {
-			tp := strings.Split(tag, "=")
-			switch tp[0] {
-			case "-":
-				ignore = true
-			case "skip":
-				numBytes, _ := strconv.Atoi(tp[1])
-				off += numBytes
-			case "default":
-				defValue, _ = strconv.ParseUint(tp[1], 0, 64)
-			}
+		tag, err := fieldTags(f)
+		if err != nil {
+			return off, fmt.Errorf("%s.%s: %w", svtn, f.Name, err)
 		}
-		if ignore {
+		off += tag.skip
+		if tag.ignore {
 			continue
 		}
 		switch fv.Kind() {
 		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			fv.SetUint(defValue)
+			fv.SetUint(tag.def)
 			off += int(ft.Size())
 		case reflect.Struct:
 			off, err := parseStruct(t, off, false /* complete */, fv)
@@ -149,3 +135,132 @@ func parseStruct(t *smbios.Table, off int, complete bool, sp interface{}) (int,
 
 	return off, nil
 }
+
+// tableVersion records the SMBIOS version a table was decoded for. Decoded
+// tables with fields that depend on the version embed it, so that their
+// methods can tell fields that the version does not define from zero values.
+type tableVersion struct {
+	version smbios.Version
+}
+
+// since reports whether the table version defines fields introduced in major.minor.
+func (tv tableVersion) since(major, minor uint8) bool {
+	return tv.version.Supports(smbios.Version{Major: major, Minor: minor})
+}
+
+// specVersion and setSpecVersion carry the version through JSON, see versionedTable.
+func (tv *tableVersion) specVersion() smbios.Version {
+	return tv.version
+}
+
+func (tv *tableVersion) setSpecVersion(v smbios.Version) {
+	tv.version = v
+}
+
+// fieldSizer is implemented by fields whose size in the table depends on their value.
+type fieldSizer interface {
+	fieldSize() int
+}
+
+// encodedSize returns the number of bytes field v occupies in the table, or -1
+// if it is not known.
+func encodedSize(v reflect.Value) int {
+	if v.CanInterface() {
+		if fs, ok := v.Interface().(fieldSizer); ok {
+			return fs.fieldSize()
+		}
+	}
+	switch v.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Array:
+		return int(v.Type().Size())
+	case reflect.String:
+		return 1
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < v.NumField(); i++ {
+			tag, err := fieldTags(v.Type().Field(i))
+			if err != nil {
+				return -1
+			}
+			size += tag.skip
+			if tag.ignore {
+				continue
+			}
+			fs := encodedSize(v.Field(i))
+			if fs < 0 {
+				return -1
+			}
+			size += fs
+		}
+		return size
+	}
+	return -1
+}
+
+// fieldTag holds the parser annotations of a struct field.
+type fieldTag struct {
+	ignore bool           // "-": the field is not read from the table.
+	skip   int            // "skip=N": N bytes precede the field.
+	since  smbios.Version // "since=M.m": the field was added in SMBIOS M.m.
+	def    uint64         // "default=X": value of an integer field beyond the table.
+}
+
+// fieldTags returns the parser annotations of f.
+func fieldTags(f reflect.StructField) (tag fieldTag, err error) {
+	for _, t := range strings.Split(f.Tag.Get(fieldTagKey), ",") {
+		tp := strings.Split(t, "=")
+		switch tp[0] {
+		case "-":
+			tag.ignore = true
+		case "skip":
+			tag.skip, _ = strconv.Atoi(tp[1])
+		case "since":
+			if tag.since, err = smbios.ParseVersion(tp[1]); err != nil {
+				return tag, err
+			}
+		case "default":
+			if tag.def, err = strconv.ParseUint(tp[1], 0, 64); err != nil {
+				return tag, err
+			}
+		}
+	}
+	return tag, nil
+}
+
+// hasField reports whether the field called name of the decoded table sp was
+// present in the table it was decoded from, given the header and SMBIOS
+// version of that table. A field is present if it fits entirely within the
+// table length and the version defines it. Like parseStruct, hasField walks
+// the fields in order to find their offsets, so the sizes of variable length
+// fields are taken from their values.
+func hasField(sp interface{}, h smbios.Header, v smbios.Version, name string) bool {
+	sv := reflect.Indirect(reflect.ValueOf(sp))
+	off := 0
+	for i := 0; i < sv.NumField(); i++ {
+		f := sv.Type().Field(i)
+		tag, err := fieldTags(f)
+		if err != nil || !v.Supports(tag.since) {
+			return false
+		}
+		// Ignored fields with skip=N are parsed separately from the N
+		// bytes that are skipped.
+		size := tag.skip
+		if !tag.ignore {
+			off += tag.skip
+			if size = encodedSize(sv.Field(i)); size < 0 {
+				return false
+			}
+		} else if size == 0 {
+			if f.Name == name {
+				return false
+			}
+			continue
+		}
+		if f.Name == name {
+			// Offsets are relative to the end of the header.
+			return 4+off+size <= int(h.Length)
+		}
+		off += size
+	}
+	return false
+}
@@ -106,6 +106,50 @@ func TestParseStruct(t *testing.T) {
 	}
 }
 
+func TestParseStructSince(t *testing.T) {
+	type versioned struct {
+		Off0 uint8
+		Off1 uint8 `smbios:"since=2.4"`
+		Off2 uint8 `smbios:"default=0xff"`
+	}
+
+	for _, tt := range []struct {
+		version smbios.Version
+		want    versioned
+	}{
+		{smbios.Version{}, versioned{1, 2, 3}},
+		{smbios.Version{Major: 2, Minor: 4}, versioned{1, 2, 3}},
+		{smbios.Version{Major: 3, Minor: 0}, versioned{1, 2, 3}},
+		{smbios.Version{Major: 2, Minor: 3}, versioned{1, 0, 0xff}},
+	} {
+		t.Run(tt.version.String(), func(t *testing.T) {
+			table := &smbios.Table{Data: []byte{1, 2, 3}, Version: tt.version}
+			var got versioned
+			if _, err := parseStruct(table, 0, false, &got); err != nil {
+				t.Fatalf("parseStruct() = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseStruct() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	var invalid struct {
+		Off0 uint8 `smbios:"since=2"`
+	}
+	if _, err := parseStruct(&smbios.Table{Data: []byte{1}}, 0, false, &invalid); err == nil {
+		t.Errorf("parseStruct() with invalid since tag = nil, want error")
+	}
+
+	var invalidDefault struct {
+		Off0 uint8
+		Off1 uint8 `smbios:"default=none"`
+	}
+	if _, err := parseStruct(&smbios.Table{Data: []byte{1}}, 0, false, &invalidDefault); err == nil {
+		t.Errorf("parseStruct() with invalid default tag = nil, want error")
+	}
+}
+
 func TestParseStructWithTPMDevice(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -192,3 +236,39 @@ func TestParseStructWithTPMDevice(t *testing.T) {
 	}
 
 }
+
+func TestHasField(t *testing.T) {
+	ci := &ChassisInfo{
+		Header:            smbios.Header{Length: 0x1c},
+		ContainedElements: ChassisContainedElements{{}, {}},
+	}
+	pi := &ProcessorInfo{
+		Header:       smbios.Header{Length: 0x30},
+		tableVersion: tableVersion{smbios.Version{Major: 2, Minor: 6}},
+	}
+	for _, tt := range []struct {
+		name  string
+		table interface{ Has(string) bool }
+		field string
+		want  bool
+	}{
+		{"first field", ci, "Manufacturer", true},
+		{"variable length field", ci, "ContainedElements", true},
+		{"after variable length field", ci, "SKUNumber", true},
+		{"beyond length", &ChassisInfo{Header: smbios.Header{Length: 0x1b}, ContainedElements: ChassisContainedElements{{}, {}}}, "SKUNumber", false},
+		{"partially beyond length", &ChassisInfo{Header: smbios.Header{Length: 0x10}}, "OEMInfo", false},
+		{"header", ci, "Header", false},
+		{"unknown field", ci, "Color", false},
+		{"version defines field", pi, "Family2", true},
+		{"version predates field", pi, "CoreCount2", false},
+		{"skipped field", &TPMDevice{Header: smbios.Header{Length: 0x8}}, "VendorID", true},
+		{"after skipped field", &TPMDevice{Header: smbios.Header{Length: 0x9}}, "MajorSpecVersion", true},
+		{"embedded table", &SystemSlots{Table: smbios.Table{Header: smbios.Header{Length: 0xd}}}, "SlotCharacteristics2", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.Has(tt.field); got != tt.want {
+				t.Errorf("Has(%q) = %v, want %v", tt.field, got, tt.want)
+			}
+		})
+	}
+}
@@ -31,8 +31,12 @@ func ParseTypedTable(t *smbios.Table) (fmt.Stringer, error) {
 		return ParseCacheInfo(t)
 	case smbios.TableTypeSystemSlots: // 9
 		return ParseSystemSlots(t)
+	case smbios.TableTypePhysicalMemoryArray: // 16
+		return ParsePhysicalMemoryArray(t)
 	case smbios.TableTypeMemoryDevice: // 17
 		return ParseMemoryDevice(t)
+	case smbios.TableTypeMemoryArrayMappedAddress: // 19
+		return ParseMemoryArrayMappedAddress(t)
 	case smbios.TableTypeIPMIDeviceInfo: // 38
 		return ParseIPMIDeviceInfo(t)
 	case smbios.TableTypeTPMDevice: // 43
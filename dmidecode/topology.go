@@ -0,0 +1,215 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"sort"
+
+	"github.com/u-root/smbios"
+)
+
+// Processor is a processor socket with its cache hierarchy resolved.
+type Processor struct {
+	*ProcessorInfo
+
+	Cores          int // Number of cores, see GetCoreCount.
+	CoresEnabled   int // Number of enabled cores, see GetCoreEnabled.
+	Threads        int // Number of threads, see GetThreadCount.
+	ThreadsEnabled int // Number of enabled threads, see GetThreadEnabled.
+
+	// Caches referenced by the processor, nil if there is none or if the
+	// handle does not refer to a cache information structure.
+	L1, L2, L3 *Cache
+}
+
+// Cache is a cache information structure referenced by a processor. Its
+// configuration is decoded by the methods of CacheInfo.
+type Cache struct {
+	*CacheInfo
+}
+
+// Processors returns all the processor sockets, with their caches resolved.
+func (i *Info) Processors() ([]*Processor, error) {
+	pis, err := i.GetProcessorInfo()
+	if err != nil {
+		return nil, err
+	}
+	var res []*Processor
+	for _, pi := range pis {
+		p := &Processor{
+			ProcessorInfo:  pi,
+			Cores:          pi.GetCoreCount(),
+			CoresEnabled:   pi.GetCoreEnabled(),
+			Threads:        pi.GetThreadCount(),
+			ThreadsEnabled: pi.GetThreadEnabled(),
+		}
+		for _, c := range []struct {
+			h    uint16
+			dest **Cache
+		}{
+			{pi.L1CacheHandle, &p.L1},
+			{pi.L2CacheHandle, &p.L2},
+			{pi.L3CacheHandle, &p.L3},
+		} {
+			if *c.dest, err = i.processorCache(pi, c.h); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// processorCache resolves a cache handle of pi.
+func (i *Info) processorCache(pi *ProcessorInfo, h uint16) (*Cache, error) {
+	// 0xFFFF is "Not Provided", or "No Cache" before SMBIOS 2.3. Some
+	// older firmware also used 0, which is normally the BIOS information.
+	if h >= firstReservedHandle || (h == 0 && !pi.since(2, 3)) {
+		return nil, nil
+	}
+	t := i.Resolve(h)
+	if t == nil || t.Type != smbios.TableTypeCacheInfo {
+		return nil, nil
+	}
+	ci, err := ParseCacheInfo(t)
+	if err != nil {
+		return nil, err
+	}
+	return &Cache{CacheInfo: ci}, nil
+}
+
+// MemoryTopology is the memory of the system, grouped by physical memory array.
+type MemoryTopology struct {
+	Arrays []*MemoryArray
+
+	// Unattached lists the memory devices whose array handle does not refer
+	// to a physical memory array.
+	Unattached []*MemorySlot
+
+	// Totals over the arrays used as system memory and unattached devices.
+	InstalledBytes   uint64 // Sum of the sizes of the installed modules.
+	MaxCapacityBytes uint64 // Sum of the maximum capacities of the arrays.
+	Slots            int    // Number of memory devices.
+	PopulatedSlots   int    // Number of memory devices with a module installed.
+
+	// Distinct known speeds, in MT/s, and configured voltages, in millivolts,
+	// of the installed modules, in increasing order.
+	Speeds   []uint32
+	Voltages []uint16
+}
+
+// MemoryArray is a physical memory array with its memory devices and mapped
+// address ranges.
+type MemoryArray struct {
+	*PhysicalMemoryArray
+
+	Slots          []*MemorySlot
+	MappedRanges   []*MemoryArrayMappedAddress
+	InstalledBytes uint64
+	PopulatedSlots int
+}
+
+// MemorySlot is a memory device, which may be an empty slot.
+type MemorySlot struct {
+	*MemoryDevice
+
+	Populated bool   // A module is installed, even if its size is unknown.
+	SizeBytes uint64 // Size of the installed module, or 0 if unknown, see GetSizeBytes.
+}
+
+// SpeedMismatch reports whether installed modules run at different speeds.
+func (mt *MemoryTopology) SpeedMismatch() bool {
+	return len(mt.Speeds) > 1
+}
+
+// VoltageMismatch reports whether installed modules are configured for
+// different voltages.
+func (mt *MemoryTopology) VoltageMismatch() bool {
+	return len(mt.Voltages) > 1
+}
+
+// MemoryTopology returns the memory devices grouped under their physical
+// memory arrays, along with totals over the whole system.
+func (i *Info) MemoryTopology() (*MemoryTopology, error) {
+	mas, err := i.GetPhysicalMemoryArrays()
+	if err != nil {
+		return nil, err
+	}
+	mds, err := i.GetMemoryDevices()
+	if err != nil {
+		return nil, err
+	}
+	mams, err := i.GetMemoryArrayMappedAddresses()
+	if err != nil {
+		return nil, err
+	}
+
+	mt := &MemoryTopology{}
+	arrays := make(map[uint16]*MemoryArray)
+	for _, ma := range mas {
+		a := &MemoryArray{PhysicalMemoryArray: ma}
+		mt.Arrays = append(mt.Arrays, a)
+		if _, ok := arrays[ma.Handle]; !ok {
+			arrays[ma.Handle] = a
+		}
+		if ma.Use == MemoryArrayUseSystemMemory {
+			mt.MaxCapacityBytes += ma.GetMaxCapacityBytes()
+		}
+	}
+	for _, mam := range mams {
+		if a, ok := arrays[mam.PhysicalMemoryArrayHandle]; ok {
+			a.MappedRanges = append(a.MappedRanges, mam)
+		}
+	}
+
+	speeds := make(map[uint32]bool)
+	voltages := make(map[uint16]bool)
+	for _, md := range mds {
+		// Size is 0 for empty slots and 0xffff for modules of unknown size.
+		s := &MemorySlot{MemoryDevice: md, Populated: md.Size != 0}
+		if md.Size != 0xffff {
+			s.SizeBytes = md.GetSizeBytes()
+		}
+		a, ok := arrays[md.PhysicalMemoryArrayHandle]
+		if ok {
+			a.Slots = append(a.Slots, s)
+			if s.Populated {
+				a.InstalledBytes += s.SizeBytes
+				a.PopulatedSlots++
+			}
+			if a.Use != MemoryArrayUseSystemMemory {
+				continue
+			}
+		} else {
+			mt.Unattached = append(mt.Unattached, s)
+		}
+		mt.Slots++
+		if !s.Populated {
+			continue
+		}
+		mt.PopulatedSlots++
+		mt.InstalledBytes += s.SizeBytes
+		if v := md.GetConfiguredSpeed(); md.Has("ConfiguredSpeed") && v != 0 {
+			speeds[v] = true
+		} else if v := md.GetSpeed(); v != 0 {
+			speeds[v] = true
+		}
+		if v := md.ConfiguredVoltage; md.Has("ConfiguredVoltage") && v != 0 {
+			voltages[v] = true
+		}
+	}
+	mt.Speeds = sortedKeys(speeds)
+	mt.Voltages = sortedKeys(voltages)
+	return mt, nil
+}
+
+func sortedKeys[T uint16 | uint32](m map[T]bool) []T {
+	var res []T
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
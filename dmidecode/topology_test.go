@@ -0,0 +1,261 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func cacheTable(handle, config, maxSize, installedSize uint16) *smbios.Table {
+	data := make([]byte, 0x0b)
+	data[0x01], data[0x02] = uint8(config), uint8(config>>8)
+	data[0x03], data[0x04] = uint8(maxSize), uint8(maxSize>>8)
+	data[0x05], data[0x06] = uint8(installedSize), uint8(installedSize>>8)
+	return handleTable(smbios.TableTypeCacheInfo, handle, data)
+}
+
+func processorTable(handle, l1, l2, l3 uint16, version smbios.Version) *smbios.Table {
+	data := make([]byte, 0x2c)
+	data[0x1f], data[0x20], data[0x21] = 4, 4, 8 // Core Count, Core Enabled, Thread Count.
+	for i, h := range []uint16{l1, l2, l3} {
+		data[0x16+2*i], data[0x17+2*i] = uint8(h), uint8(h>>8)
+	}
+	t := handleTable(smbios.TableTypeProcessorInfo, handle, data)
+	t.Version = version
+	return t
+}
+
+func TestProcessors(t *testing.T) {
+	info := &Info{
+		Tables: smbios.Tables{
+			handleTable(smbios.TableTypeBIOSInfo, 0x00, make([]byte, 0x0e)),
+			// Enabled, Internal, Not Socketed, Level 1, Write Back.
+			cacheTable(0x01, 0x0180, 0x0020, 0x0020),
+			// Enabled, External, Socketed, Level 2, Write Through.
+			cacheTable(0x02, 0x00a9, 0x8004, 0x8002),
+			processorTable(0x10, 0x01, 0x02, 0xffff, smbios.Version{Major: 2, Minor: 7}),
+			// Before SMBIOS 2.3, 0 means there is no cache.
+			processorTable(0x11, 0x00, 0x02, 0x03, smbios.Version{Major: 2, Minor: 2}),
+		},
+	}
+	ps, err := info.Processors()
+	if err != nil {
+		t.Fatalf("Processors() = %v", err)
+	}
+	if len(ps) != 2 {
+		t.Fatalf("Processors() returned %d processors, want 2", len(ps))
+	}
+
+	p := ps[0]
+	if p.Handle != 0x10 || p.Cores != 4 || p.CoresEnabled != 4 || p.Threads != 8 {
+		t.Errorf("Processors()[0] = handle 0x%04X, %d/%d cores, %d threads", p.Handle, p.Cores, p.CoresEnabled, p.Threads)
+	}
+	if p.L1 == nil || p.L2 == nil {
+		t.Fatalf("Processors()[0] caches = %v, %v, want non-nil", p.L1, p.L2)
+	}
+	if p.L3 != nil {
+		t.Errorf("Processors()[0].L3 = %v, want nil", p.L3)
+	}
+	type cacheConfig struct {
+		Level              int
+		Socketed           bool
+		Location           CacheLocation
+		Enabled            bool
+		OperationalMode    CacheOperationalMode
+		MaxSizeBytes       uint64
+		InstalledSizeBytes uint64
+	}
+	for _, tt := range []struct {
+		name string
+		got  *Cache
+		want cacheConfig
+	}{
+		{
+			name: "L1",
+			got:  p.L1,
+			want: cacheConfig{
+				Level:              1,
+				Location:           CacheLocationInternal,
+				Enabled:            true,
+				OperationalMode:    CacheOperationalModeWriteBack,
+				MaxSizeBytes:       32 << 10,
+				InstalledSizeBytes: 32 << 10,
+			},
+		},
+		{
+			name: "L2",
+			got:  p.L2,
+			want: cacheConfig{
+				Level:              2,
+				Socketed:           true,
+				Location:           CacheLocationExternal,
+				Enabled:            true,
+				OperationalMode:    CacheOperationalModeWriteThrough,
+				MaxSizeBytes:       256 << 10,
+				InstalledSizeBytes: 128 << 10,
+			},
+		},
+	} {
+		got := cacheConfig{
+			Level:              tt.got.Level(),
+			Socketed:           tt.got.Socketed(),
+			Location:           tt.got.Location(),
+			Enabled:            tt.got.Enabled(),
+			OperationalMode:    tt.got.OperationalMode(),
+			MaxSizeBytes:       tt.got.MaxSizeBytes(),
+			InstalledSizeBytes: tt.got.InstalledSizeBytes(),
+		}
+		if got != tt.want {
+			t.Errorf("%s = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+	if p.L2.CacheInfo.Handle != 0x02 {
+		t.Errorf("L2 handle = 0x%04X, want 0x0002", p.L2.CacheInfo.Handle)
+	}
+
+	p = ps[1]
+	if p.L1 != nil || p.L2 == nil || p.L3 != nil {
+		t.Errorf("Processors()[1] caches = %v, %v, %v, want nil, L2, nil", p.L1, p.L2, p.L3)
+	}
+
+	short := cacheTable(0x03, 0, 0, 0)
+	short.Length, short.Data = 0x0a, short.Data[:6]
+	info = &Info{
+		Tables: smbios.Tables{
+			short,
+			processorTable(0x10, 0xffff, 0xffff, 0x03, smbios.Version{Major: 2, Minor: 7}),
+		},
+	}
+	if _, err := info.Processors(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Processors() with a short cache = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func memoryDeviceTable(handle, array, sizeMB, speed, configuredSpeed, configuredVoltage uint16) *smbios.Table {
+	data := make([]byte, 0x24)
+	binary.LittleEndian.PutUint16(data[0x00:], array)
+	binary.LittleEndian.PutUint16(data[0x02:], 0xfffe)
+	binary.LittleEndian.PutUint16(data[0x08:], sizeMB)
+	binary.LittleEndian.PutUint16(data[0x11:], speed)
+	binary.LittleEndian.PutUint16(data[0x1c:], configuredSpeed)
+	binary.LittleEndian.PutUint16(data[0x22:], configuredVoltage)
+	t := handleTable(smbios.TableTypeMemoryDevice, handle, data)
+	t.Version = smbios.Version{Major: 2, Minor: 8}
+	return t
+}
+
+func TestMemoryTopology(t *testing.T) {
+	v27 := smbios.Version{Major: 2, Minor: 7}
+	info := &Info{
+		Tables: smbios.Tables{
+			memoryArrayTable(0x10, MemoryArrayUseSystemMemory, 0x02000000, 0, v27),
+			memoryDeviceTable(0x11, 0x10, 8192, 2400, 2400, 1200),
+			memoryDeviceTable(0x12, 0x10, 0, 0, 0, 0),
+			memoryDeviceTable(0x13, 0x10, 4096, 2666, 2133, 1200),
+			memoryArrayTable(0x20, MemoryArrayUseVideoMemory, 0x00100000, 0, v27),
+			memoryDeviceTable(0x21, 0x20, 1024, 1600, 1600, 1500),
+			// Array handle not found.
+			memoryDeviceTable(0x31, 0x99, 2048, 2400, 0, 0),
+			mappedAddressTable(0x40, 0x10, 0, 0x00bfffff, 0, 0),
+		},
+	}
+	mt, err := info.MemoryTopology()
+	if err != nil {
+		t.Fatalf("MemoryTopology() = %v", err)
+	}
+
+	if len(mt.Arrays) != 2 {
+		t.Fatalf("MemoryTopology() has %d arrays, want 2", len(mt.Arrays))
+	}
+	a := mt.Arrays[0]
+	if a.Handle != 0x10 || len(a.Slots) != 3 || len(a.MappedRanges) != 1 || a.InstalledBytes != 12<<30 || a.PopulatedSlots != 2 {
+		t.Errorf("Arrays[0] = handle 0x%04X, %d slots, %d ranges, %d bytes, %d populated", a.Handle, len(a.Slots), len(a.MappedRanges), a.InstalledBytes, a.PopulatedSlots)
+	}
+	var populated []bool
+	for _, s := range a.Slots {
+		populated = append(populated, s.Populated)
+	}
+	if want := []bool{true, false, true}; !reflect.DeepEqual(populated, want) {
+		t.Errorf("Arrays[0] populated slots = %v, want %v", populated, want)
+	}
+	if a := mt.Arrays[1]; a.Handle != 0x20 || len(a.Slots) != 1 || a.InstalledBytes != 1<<30 {
+		t.Errorf("Arrays[1] = handle 0x%04X, %d slots, %d bytes", a.Handle, len(a.Slots), a.InstalledBytes)
+	}
+	if len(mt.Unattached) != 1 || mt.Unattached[0].Handle != 0x31 || mt.Unattached[0].SizeBytes != 2<<30 {
+		t.Errorf("Unattached = %v, want memory device 0x0031", mt.Unattached)
+	}
+
+	// Video memory is not part of the totals.
+	if mt.InstalledBytes != 14<<30 || mt.MaxCapacityBytes != 32<<30 || mt.Slots != 4 || mt.PopulatedSlots != 3 {
+		t.Errorf("totals = %d installed, %d maximum, %d/%d slots populated", mt.InstalledBytes, mt.MaxCapacityBytes, mt.PopulatedSlots, mt.Slots)
+	}
+	if want := []uint32{2133, 2400}; !reflect.DeepEqual(mt.Speeds, want) || !mt.SpeedMismatch() {
+		t.Errorf("Speeds = %v, SpeedMismatch() = %t, want %v, true", mt.Speeds, mt.SpeedMismatch(), want)
+	}
+	if want := []uint16{1200}; !reflect.DeepEqual(mt.Voltages, want) || mt.VoltageMismatch() {
+		t.Errorf("Voltages = %v, VoltageMismatch() = %t, want %v, false", mt.Voltages, mt.VoltageMismatch(), want)
+	}
+}
+
+func TestMemoryTopologyUnknownSize(t *testing.T) {
+	v27 := smbios.Version{Major: 2, Minor: 7}
+	info := &Info{
+		Tables: smbios.Tables{
+			memoryArrayTable(0x10, MemoryArrayUseSystemMemory, 0x02000000, 0, v27),
+			memoryDeviceTable(0x11, 0x10, 8192, 2400, 2400, 1200),
+			memoryDeviceTable(0x12, 0x10, 0xffff, 2400, 2400, 1200),
+			memoryDeviceTable(0x13, 0x10, 0, 0, 0, 0),
+		},
+	}
+	mt, err := info.MemoryTopology()
+	if err != nil {
+		t.Fatalf("MemoryTopology() = %v", err)
+	}
+	var populated []bool
+	for _, s := range mt.Arrays[0].Slots {
+		populated = append(populated, s.Populated)
+	}
+	if want := []bool{true, true, false}; !reflect.DeepEqual(populated, want) {
+		t.Errorf("populated slots = %v, want %v", populated, want)
+	}
+	if a := mt.Arrays[0]; a.PopulatedSlots != 2 || a.InstalledBytes != 8<<30 {
+		t.Errorf("Arrays[0] = %d populated, %d bytes, want 2, %d", a.PopulatedSlots, a.InstalledBytes, 8<<30)
+	}
+	if mt.PopulatedSlots != 2 || mt.Slots != 3 || mt.InstalledBytes != 8<<30 {
+		t.Errorf("totals = %d installed, %d/%d slots populated", mt.InstalledBytes, mt.PopulatedSlots, mt.Slots)
+	}
+}
+
+func TestMemoryTopologyExtendedSpeed(t *testing.T) {
+	extendedSpeedTable := func(handle uint16, speed uint32) *smbios.Table {
+		tbl := memoryDeviceTable(handle, 0x10, 16384, 0xffff, 0xffff, 1100)
+		tbl.Data = append(tbl.Data, make([]byte, 0x58-len(tbl.Data))...)
+		binary.LittleEndian.PutUint32(tbl.Data[0x50:], speed)
+		binary.LittleEndian.PutUint32(tbl.Data[0x54:], speed)
+		tbl.Length = uint8(4 + len(tbl.Data))
+		tbl.Version = smbios.Version{Major: 3, Minor: 3}
+		return tbl
+	}
+	info := &Info{
+		Tables: smbios.Tables{
+			memoryArrayTable(0x10, MemoryArrayUseSystemMemory, 0x02000000, 0, smbios.Version{Major: 3, Minor: 3}),
+			extendedSpeedTable(0x11, 70000),
+			extendedSpeedTable(0x12, 80000),
+		},
+	}
+	mt, err := info.MemoryTopology()
+	if err != nil {
+		t.Fatalf("MemoryTopology() = %v", err)
+	}
+	if want := []uint32{70000, 80000}; !reflect.DeepEqual(mt.Speeds, want) || !mt.SpeedMismatch() {
+		t.Errorf("Speeds = %v, SpeedMismatch() = %t, want %v, true", mt.Speeds, mt.SpeedMismatch(), want)
+	}
+}
@@ -5,29 +5,35 @@
 package dmidecode
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/u-root/smbios"
 )
 
+// ErrInvalidReleaseDate is returned when the BIOS release date cannot be parsed.
+var ErrInvalidReleaseDate = errors.New("invalid BIOS release date")
+
 // BIOSInfo is defined in DSP0134 7.1.
 type BIOSInfo struct {
-	smbios.Header          `smbios:"-"`
+	smbios.Header          `smbios:"-" json:"Header"`
+	tableVersion           `smbios:"-"`
 	Vendor                 string        // 04h
 	Version                string        // 05h
 	StartingAddressSegment uint16        // 06h
 	ReleaseDate            string        // 08h
 	ROMSize                uint8         // 09h
 	Characteristics        BIOSChars     // 0Ah
-	CharacteristicsExt1    BIOSCharsExt1 // 12h
+	CharacteristicsExt1    BIOSCharsExt1 `smbios:"since=2.4"` // 12h
 	CharacteristicsExt2    BIOSCharsExt2 // 13h
 	BIOSMajor              uint8         `smbios:"default=0xff"` // 14h
 	BIOSMinor              uint8         `smbios:"default=0xff"` // 15h
 	ECMajor                uint8         `smbios:"default=0xff"` // 16h
 	ECMinor                uint8         `smbios:"default=0xff"` // 17h
-	ExtendedROMSize        uint16        // 18h
+	ExtendedROMSize        uint16        `smbios:"since=3.1"`    // 18h
 }
 
 // ParseBIOSInfo parses a generic Table into BIOSInfo.
@@ -39,7 +45,8 @@ func ParseBIOSInfo(t *smbios.Table) (*BIOSInfo, error) {
 		return nil, fmt.Errorf("%w: BIOS info table must be at least %d bytes", io.ErrUnexpectedEOF, 0x12)
 	}
 	bi := &BIOSInfo{
-		Header: t.Header,
+		Header:       t.Header,
+		tableVersion: tableVersion{t.Version},
 	}
 	if _, err := parseStruct(t, 0 /* off */, false /* complete */, bi); err != nil {
 		return nil, err
@@ -47,6 +54,12 @@ func ParseBIOSInfo(t *smbios.Table) (*BIOSInfo, error) {
 	return bi, nil
 }
 
+// Has reports whether the table contains field, the name of a BIOSInfo field.
+// Absent fields are zero or have their default value.
+func (bi *BIOSInfo) Has(field string) bool {
+	return hasField(bi, bi.Header, bi.version, field)
+}
+
 // ROMSizeBytes returns ROM size in bytes.
 func (bi *BIOSInfo) ROMSizeBytes() uint64 {
 	if bi.ROMSize != 0xff || bi.ExtendedROMSize == 0 {
@@ -66,6 +79,63 @@ func (bi *BIOSInfo) ROMSizeBytes() uint64 {
 	return (extSize & 0x3fff) * multiplier
 }
 
+// releaseDateLayouts are the layouts of release dates found in the wild. Month
+// and day may have one or two digits.
+var releaseDateLayouts = []string{
+	"1/2/2006", // DSP0134 mm/dd/yyyy.
+	"1/2/06",   // mm/dd/yy, from before SMBIOS 2.3.
+	"1-2-2006",
+	"2006-1-2",
+	"2006/1/2",
+}
+
+// ReleaseTime returns the BIOS release date, at midnight UTC. Two-digit years
+// from 69 on are in the 20th century, the others in the 21st.
+func (bi *BIOSInfo) ReleaseTime() (time.Time, error) {
+	s := strings.TrimSpace(bi.ReleaseDate)
+	for _, layout := range releaseDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidReleaseDate, bi.ReleaseDate)
+}
+
+// FirmwareRevision is the major and minor release of the system BIOS or of
+// the embedded controller firmware.
+type FirmwareRevision struct {
+	Major uint8
+	Minor uint8
+}
+
+func (r FirmwareRevision) String() string {
+	return fmt.Sprintf("%d.%d", r.Major, r.Minor)
+}
+
+// Compare returns -1, 0 or 1 depending on whether r is older than, the same
+// as or newer than o.
+func (r FirmwareRevision) Compare(o FirmwareRevision) int {
+	switch {
+	case r == o:
+		return 0
+	case r.Major < o.Major, r.Major == o.Major && r.Minor < o.Minor:
+		return -1
+	}
+	return 1
+}
+
+// BIOSRevision returns the release of the system BIOS. It reports false if
+// the table does not give one.
+func (bi *BIOSInfo) BIOSRevision() (FirmwareRevision, bool) {
+	return FirmwareRevision{bi.BIOSMajor, bi.BIOSMinor}, bi.BIOSMajor != 0xff && bi.BIOSMinor != 0xff
+}
+
+// ECRevision returns the release of the embedded controller firmware. It
+// reports false if the system has no field-upgradeable embedded controller.
+func (bi *BIOSInfo) ECRevision() (FirmwareRevision, bool) {
+	return FirmwareRevision{bi.ECMajor, bi.ECMinor}, bi.ECMajor != 0xff && bi.ECMinor != 0xff
+}
+
 func (bi *BIOSInfo) String() string {
 	lines := []string{
 		bi.Header.String(),
@@ -85,11 +155,11 @@ func (bi *BIOSInfo) String() string {
 		bi.CharacteristicsExt1.String(),
 		bi.CharacteristicsExt2.String(),
 	)
-	if bi.BIOSMajor != 0xff { // 2.4+
-		lines = append(lines, fmt.Sprintf("\tBIOS Revision: %d.%d", bi.BIOSMajor, bi.BIOSMinor))
+	if rev, ok := bi.BIOSRevision(); ok { // 2.4+
+		lines = append(lines, fmt.Sprintf("\tBIOS Revision: %s", rev))
 	}
-	if bi.ECMajor != 0xff {
-		lines = append(lines, fmt.Sprintf("\tFirmware Revision: %d.%d", bi.ECMajor, bi.ECMinor))
+	if rev, ok := bi.ECRevision(); ok {
+		lines = append(lines, fmt.Sprintf("\tFirmware Revision: %s", rev))
 	}
 	return strings.Join(lines, "\n")
 }
@@ -210,6 +280,21 @@ func (v BIOSChars) String() string {
 	return strings.Join(lines, "\n")
 }
 
+// Flags returns the characteristics that are set, in bit order.
+func (v BIOSChars) Flags() []BIOSChars {
+	return setFlags(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v BIOSChars) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, biosCharToString)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *BIOSChars) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, biosCharToString)
+}
+
 // BIOSCharsExt1 is defined in DSP0134 7.1.2.1.
 type BIOSCharsExt1 uint8
 
@@ -246,6 +331,21 @@ func (v BIOSCharsExt1) String() string {
 	return strings.Join(lines, "\n")
 }
 
+// Flags returns the characteristics that are set, in bit order.
+func (v BIOSCharsExt1) Flags() []BIOSCharsExt1 {
+	return setFlags(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v BIOSCharsExt1) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, biosCharsExt1Map)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *BIOSCharsExt1) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, biosCharsExt1Map)
+}
+
 // BIOSCharsExt2 is defined in DSP0134 7.1.2.2.
 type BIOSCharsExt2 uint8
 
@@ -275,3 +375,18 @@ func (v BIOSCharsExt2) String() string {
 	}
 	return strings.Join(lines, "\n")
 }
+
+// Flags returns the characteristics that are set, in bit order.
+func (v BIOSCharsExt2) Flags() []BIOSCharsExt2 {
+	return setFlags(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v BIOSCharsExt2) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, biosCharsExt2Map)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *BIOSCharsExt2) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, biosCharsExt2Map)
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/u-root/smbios"
 )
@@ -209,6 +210,99 @@ BIOS Information
 	}
 }
 
+func TestBIOSInfoReleaseTime(t *testing.T) {
+	for _, tt := range []struct {
+		date string
+		want time.Time
+		err  error
+	}{
+		{date: "01/16/2019", want: time.Date(2019, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{date: "12/06/2013 ", want: time.Date(2013, 12, 6, 0, 0, 0, 0, time.UTC)},
+		{date: "8/3/2010", want: time.Date(2010, 8, 3, 0, 0, 0, 0, time.UTC)},
+		{date: "06/08/99", want: time.Date(1999, 6, 8, 0, 0, 0, 0, time.UTC)},
+		{date: "06/08/05", want: time.Date(2005, 6, 8, 0, 0, 0, 0, time.UTC)},
+		{date: "2021/11/23", want: time.Date(2021, 11, 23, 0, 0, 0, 0, time.UTC)},
+		{date: "2021-11-23", want: time.Date(2021, 11, 23, 0, 0, 0, 0, time.UTC)},
+		{date: "11-23-2021", want: time.Date(2021, 11, 23, 0, 0, 0, 0, time.UTC)},
+		{date: "", err: ErrInvalidReleaseDate},
+		{date: "13/01/2020", err: ErrInvalidReleaseDate},
+		{date: "To Be Filled By O.E.M.", err: ErrInvalidReleaseDate},
+	} {
+		bi := BIOSInfo{ReleaseDate: tt.date}
+		got, err := bi.ReleaseTime()
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ReleaseTime(%q) = %v, want %v", tt.date, err, tt.err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ReleaseTime(%q) = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestBIOSInfoRevisions(t *testing.T) {
+	for _, tt := range []struct {
+		bi       BIOSInfo
+		bios, ec FirmwareRevision
+		biosOK   bool
+		ecOK     bool
+	}{
+		{
+			bi: BIOSInfo{BIOSMajor: 0xff, BIOSMinor: 0xff, ECMajor: 0xff, ECMinor: 0xff},
+		},
+		{
+			bi:     BIOSInfo{BIOSMajor: 5, BIOSMinor: 14, ECMajor: 1, ECMinor: 33},
+			bios:   FirmwareRevision{5, 14},
+			biosOK: true,
+			ec:     FirmwareRevision{1, 33},
+			ecOK:   true,
+		},
+		{
+			bi:     BIOSInfo{BIOSMajor: 4, BIOSMinor: 6, ECMajor: 0xff, ECMinor: 0xff},
+			bios:   FirmwareRevision{4, 6},
+			biosOK: true,
+		},
+	} {
+		if got, ok := tt.bi.BIOSRevision(); ok != tt.biosOK || (ok && got != tt.bios) {
+			t.Errorf("BIOSRevision() = %v, %v, want %v, %v", got, ok, tt.bios, tt.biosOK)
+		}
+		if got, ok := tt.bi.ECRevision(); ok != tt.ecOK || (ok && got != tt.ec) {
+			t.Errorf("ECRevision() = %v, %v, want %v, %v", got, ok, tt.ec, tt.ecOK)
+		}
+	}
+}
+
+func TestFirmwareRevisionCompare(t *testing.T) {
+	for _, tt := range []struct {
+		a, b FirmwareRevision
+		want int
+	}{
+		{FirmwareRevision{1, 2}, FirmwareRevision{1, 2}, 0},
+		{FirmwareRevision{1, 2}, FirmwareRevision{1, 10}, -1},
+		{FirmwareRevision{1, 10}, FirmwareRevision{2, 0}, -1},
+		{FirmwareRevision{2, 0}, FirmwareRevision{1, 10}, 1},
+		{FirmwareRevision{1, 10}, FirmwareRevision{1, 2}, 1},
+	} {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBIOSCharsFlags(t *testing.T) {
+	if got, want := BIOSChars(0x8|0x80|1<<40).Flags(), []BIOSChars{BIOSCharsAreNotSupported, BIOSCharsPCI, 1 << 40}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BIOSChars.Flags() = %v, want %v", got, want)
+	}
+	if got, want := BIOSCharsExt1(0x81).Flags(), []BIOSCharsExt1{BIOSCharsExt1ACPI, BIOSCharsExt1SmartBattery}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BIOSCharsExt1.Flags() = %v, want %v", got, want)
+	}
+	if got, want := BIOSCharsExt2(0x0c).Flags(), []BIOSCharsExt2{BIOSCharsExt2TargetedContentDistribution, BIOSCharsExt2UEFISpecification}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BIOSCharsExt2.Flags() = %v, want %v", got, want)
+	}
+	if got := BIOSCharsExt2(0).Flags(); len(got) != 0 {
+		t.Errorf("BIOSCharsExt2(0).Flags() = %v, want none", got)
+	}
+}
+
 func TestROMSizeBytes(t *testing.T) {
 	for _, tt := range []struct {
 		name string
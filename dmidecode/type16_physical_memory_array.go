@@ -0,0 +1,182 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/u-root/smbios"
+)
+
+// PhysicalMemoryArray is defined in DSP0134 7.17.
+type PhysicalMemoryArray struct {
+	smbios.Header           `smbios:"-" json:"Header"`
+	tableVersion            `smbios:"-"`
+	Location                MemoryArrayLocation        // 04h
+	Use                     MemoryArrayUse             // 05h
+	ErrorCorrection         MemoryArrayErrorCorrection // 06h
+	MaximumCapacity         uint32                     // 07h
+	MemoryErrorInfoHandle   uint16                     // 0Bh
+	NumberOfMemoryDevices   uint16                     // 0Dh
+	ExtendedMaximumCapacity uint64                     `smbios:"since=2.7"` // 0Fh
+}
+
+// ParsePhysicalMemoryArray parses a generic smbios.Table into PhysicalMemoryArray.
+func ParsePhysicalMemoryArray(t *smbios.Table) (*PhysicalMemoryArray, error) {
+	if t.Type != smbios.TableTypePhysicalMemoryArray {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedTableType, t.Type)
+	}
+	if t.Len() < 0xf {
+		return nil, fmt.Errorf("%w: physical memory array table must be at least %d bytes", io.ErrUnexpectedEOF, 0xf)
+	}
+	ma := &PhysicalMemoryArray{Header: t.Header, tableVersion: tableVersion{t.Version}}
+	if _, err := parseStruct(t, 0 /* off */, false /* complete */, ma); err != nil {
+		return nil, err
+	}
+	return ma, nil
+}
+
+// Has reports whether the table contains field, the name of a
+// PhysicalMemoryArray field. Absent fields are zero.
+func (ma *PhysicalMemoryArray) Has(field string) bool {
+	return hasField(ma, ma.Header, ma.version, field)
+}
+
+// GetMaxCapacityBytes returns the maximum memory capacity of the array, in
+// bytes, taken from the appropriate field. It is 0 if unknown.
+func (ma *PhysicalMemoryArray) GetMaxCapacityBytes() uint64 {
+	if ma.MaximumCapacity == 0x80000000 {
+		return ma.ExtendedMaximumCapacity
+	}
+	return uint64(ma.MaximumCapacity) * 1024
+}
+
+func (ma *PhysicalMemoryArray) String() string {
+	capStr := "Unknown"
+	if c := ma.GetMaxCapacityBytes(); c > 0 {
+		capStr = kmgt(c)
+	}
+	lines := []string{
+		ma.Header.String(),
+		fmt.Sprintf("Location: %s", ma.Location),
+		fmt.Sprintf("Use: %s", ma.Use),
+		fmt.Sprintf("Error Correction Type: %s", ma.ErrorCorrection),
+		fmt.Sprintf("Maximum Capacity: %s", capStr),
+		fmt.Sprintf("Error Information Handle: %s", memoryErrorInfoHandleStr(ma.MemoryErrorInfoHandle)),
+		fmt.Sprintf("Number Of Devices: %d", ma.NumberOfMemoryDevices),
+	}
+	return strings.Join(lines, "\n\t")
+}
+
+// MemoryArrayLocation is defined in DSP0134 7.17.1.
+type MemoryArrayLocation uint8
+
+// MemoryArrayLocation values are defined in DSP0134 7.17.1.
+const (
+	MemoryArrayLocationOther                 MemoryArrayLocation = 0x01 // Other
+	MemoryArrayLocationUnknown               MemoryArrayLocation = 0x02 // Unknown
+	MemoryArrayLocationSystemBoard           MemoryArrayLocation = 0x03 // System board or motherboard
+	MemoryArrayLocationISAAddonCard          MemoryArrayLocation = 0x04 // ISA add-on card
+	MemoryArrayLocationEISAAddonCard         MemoryArrayLocation = 0x05 // EISA add-on card
+	MemoryArrayLocationPCIAddonCard          MemoryArrayLocation = 0x06 // PCI add-on card
+	MemoryArrayLocationMCAAddonCard          MemoryArrayLocation = 0x07 // MCA add-on card
+	MemoryArrayLocationPCMCIAAddonCard       MemoryArrayLocation = 0x08 // PCMCIA add-on card
+	MemoryArrayLocationProprietaryAddonCard  MemoryArrayLocation = 0x09 // Proprietary add-on card
+	MemoryArrayLocationNuBus                 MemoryArrayLocation = 0x0a // NuBus
+	MemoryArrayLocationPC98C20AddonCard      MemoryArrayLocation = 0xa0 // PC-98/C20 add-on card
+	MemoryArrayLocationPC98C24AddonCard      MemoryArrayLocation = 0xa1 // PC-98/C24 add-on card
+	MemoryArrayLocationPC98EAddonCard        MemoryArrayLocation = 0xa2 // PC-98/E add-on card
+	MemoryArrayLocationPC98LocalBusAddonCard MemoryArrayLocation = 0xa3 // PC-98/Local bus add-on card
+	MemoryArrayLocationCXLAddonCard          MemoryArrayLocation = 0xa4 // CXL add-on card
+)
+
+var memoryArrayLocationStr = map[MemoryArrayLocation]string{
+	MemoryArrayLocationOther:                 "Other",
+	MemoryArrayLocationUnknown:               "Unknown",
+	MemoryArrayLocationSystemBoard:           "System Board Or Motherboard",
+	MemoryArrayLocationISAAddonCard:          "ISA Add-on Card",
+	MemoryArrayLocationEISAAddonCard:         "EISA Add-on Card",
+	MemoryArrayLocationPCIAddonCard:          "PCI Add-on Card",
+	MemoryArrayLocationMCAAddonCard:          "MCA Add-on Card",
+	MemoryArrayLocationPCMCIAAddonCard:       "PCMCIA Add-on Card",
+	MemoryArrayLocationProprietaryAddonCard:  "Proprietary Add-on Card",
+	MemoryArrayLocationNuBus:                 "NuBus",
+	MemoryArrayLocationPC98C20AddonCard:      "PC-98/C20 Add-on Card",
+	MemoryArrayLocationPC98C24AddonCard:      "PC-98/C24 Add-on Card",
+	MemoryArrayLocationPC98EAddonCard:        "PC-98/E Add-on Card",
+	MemoryArrayLocationPC98LocalBusAddonCard: "PC-98/Local Bus Add-on Card",
+	MemoryArrayLocationCXLAddonCard:          "CXL Add-on Card",
+}
+
+func (v MemoryArrayLocation) String() string {
+	if name, ok := memoryArrayLocationStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
+
+// MemoryArrayUse is defined in DSP0134 7.17.2.
+type MemoryArrayUse uint8
+
+// MemoryArrayUse values are defined in DSP0134 7.17.2.
+const (
+	MemoryArrayUseOther          MemoryArrayUse = 0x01 // Other
+	MemoryArrayUseUnknown        MemoryArrayUse = 0x02 // Unknown
+	MemoryArrayUseSystemMemory   MemoryArrayUse = 0x03 // System memory
+	MemoryArrayUseVideoMemory    MemoryArrayUse = 0x04 // Video memory
+	MemoryArrayUseFlashMemory    MemoryArrayUse = 0x05 // Flash memory
+	MemoryArrayUseNonvolatileRAM MemoryArrayUse = 0x06 // Non-volatile RAM
+	MemoryArrayUseCacheMemory    MemoryArrayUse = 0x07 // Cache memory
+)
+
+var memoryArrayUseStr = map[MemoryArrayUse]string{
+	MemoryArrayUseOther:          "Other",
+	MemoryArrayUseUnknown:        "Unknown",
+	MemoryArrayUseSystemMemory:   "System Memory",
+	MemoryArrayUseVideoMemory:    "Video Memory",
+	MemoryArrayUseFlashMemory:    "Flash Memory",
+	MemoryArrayUseNonvolatileRAM: "Non-volatile RAM",
+	MemoryArrayUseCacheMemory:    "Cache Memory",
+}
+
+func (v MemoryArrayUse) String() string {
+	if name, ok := memoryArrayUseStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
+
+// MemoryArrayErrorCorrection is defined in DSP0134 7.17.3.
+type MemoryArrayErrorCorrection uint8
+
+// MemoryArrayErrorCorrection values are defined in DSP0134 7.17.3.
+const (
+	MemoryArrayErrorCorrectionOther        MemoryArrayErrorCorrection = 0x01 // Other
+	MemoryArrayErrorCorrectionUnknown      MemoryArrayErrorCorrection = 0x02 // Unknown
+	MemoryArrayErrorCorrectionNone         MemoryArrayErrorCorrection = 0x03 // None
+	MemoryArrayErrorCorrectionParity       MemoryArrayErrorCorrection = 0x04 // Parity
+	MemoryArrayErrorCorrectionSinglebitECC MemoryArrayErrorCorrection = 0x05 // Single-bit ECC
+	MemoryArrayErrorCorrectionMultibitECC  MemoryArrayErrorCorrection = 0x06 // Multi-bit ECC
+	MemoryArrayErrorCorrectionCRC          MemoryArrayErrorCorrection = 0x07 // CRC
+)
+
+var memoryArrayErrorCorrectionStr = map[MemoryArrayErrorCorrection]string{
+	MemoryArrayErrorCorrectionOther:        "Other",
+	MemoryArrayErrorCorrectionUnknown:      "Unknown",
+	MemoryArrayErrorCorrectionNone:         "None",
+	MemoryArrayErrorCorrectionParity:       "Parity",
+	MemoryArrayErrorCorrectionSinglebitECC: "Single-bit ECC",
+	MemoryArrayErrorCorrectionMultibitECC:  "Multi-bit ECC",
+	MemoryArrayErrorCorrectionCRC:          "CRC",
+}
+
+func (v MemoryArrayErrorCorrection) String() string {
+	if name, ok := memoryArrayErrorCorrectionStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
@@ -0,0 +1,114 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func memoryArrayTable(handle uint16, use MemoryArrayUse, maxCapacityKB uint32, extended uint64, version smbios.Version) *smbios.Table {
+	data := make([]byte, 0x13)
+	data[0x00] = uint8(MemoryArrayLocationSystemBoard)
+	data[0x01] = uint8(use)
+	data[0x02] = uint8(MemoryArrayErrorCorrectionMultibitECC)
+	binary.LittleEndian.PutUint32(data[0x03:], maxCapacityKB)
+	binary.LittleEndian.PutUint16(data[0x07:], 0xfffe)
+	binary.LittleEndian.PutUint16(data[0x09:], 4)
+	binary.LittleEndian.PutUint64(data[0x0b:], extended)
+	t := handleTable(smbios.TableTypePhysicalMemoryArray, handle, data)
+	t.Version = version
+	return t
+}
+
+func TestParsePhysicalMemoryArray(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		table     *smbios.Table
+		wantBytes uint64
+		want      string
+	}{
+		{
+			name:      "Maximum Capacity",
+			table:     memoryArrayTable(0x10, MemoryArrayUseSystemMemory, 0x02000000, 0, smbios.Version{Major: 2, Minor: 7}),
+			wantBytes: 32 << 30,
+			want: `Handle 0x0010, DMI type 16, 23 bytes
+Physical Memory Array
+	Location: System Board Or Motherboard
+	Use: System Memory
+	Error Correction Type: Multi-bit ECC
+	Maximum Capacity: 32 GB
+	Error Information Handle: Not Provided
+	Number Of Devices: 4`,
+		},
+		{
+			name:      "Extended Maximum Capacity",
+			table:     memoryArrayTable(0x10, MemoryArrayUseVideoMemory, 0x80000000, 4<<40, smbios.Version{Major: 3, Minor: 0}),
+			wantBytes: 4 << 40,
+			want: `Handle 0x0010, DMI type 16, 23 bytes
+Physical Memory Array
+	Location: System Board Or Motherboard
+	Use: Video Memory
+	Error Correction Type: Multi-bit ECC
+	Maximum Capacity: 4 TB
+	Error Information Handle: Not Provided
+	Number Of Devices: 4`,
+		},
+		{
+			name:      "Unknown Maximum Capacity before 2.7",
+			table:     memoryArrayTable(0x10, MemoryArrayUseSystemMemory, 0x80000000, 4<<40, smbios.Version{Major: 2, Minor: 6}),
+			wantBytes: 0,
+			want: `Handle 0x0010, DMI type 16, 23 bytes
+Physical Memory Array
+	Location: System Board Or Motherboard
+	Use: System Memory
+	Error Correction Type: Multi-bit ECC
+	Maximum Capacity: Unknown
+	Error Information Handle: Not Provided
+	Number Of Devices: 4`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ma, err := ParsePhysicalMemoryArray(tt.table)
+			if err != nil {
+				t.Fatalf("ParsePhysicalMemoryArray() = %v", err)
+			}
+			if got := ma.GetMaxCapacityBytes(); got != tt.wantBytes {
+				t.Errorf("GetMaxCapacityBytes() = %d, want %d", got, tt.wantBytes)
+			}
+			if got := ma.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParsePhysicalMemoryArray(handleTable(smbios.TableTypeMemoryDevice, 0, nil)); !errors.Is(err, ErrUnexpectedTableType) {
+		t.Errorf("ParsePhysicalMemoryArray(type 17) = %v, want %v", err, ErrUnexpectedTableType)
+	}
+	if _, err := ParsePhysicalMemoryArray(handleTable(smbios.TableTypePhysicalMemoryArray, 0, make([]byte, 0x0a))); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ParsePhysicalMemoryArray(short) = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestMemoryArrayEnumString(t *testing.T) {
+	for _, tt := range []struct {
+		got, want string
+	}{
+		{MemoryArrayLocationPC98LocalBusAddonCard.String(), "PC-98/Local Bus Add-on Card"},
+		{MemoryArrayLocation(0x0b).String(), "0xb"},
+		{MemoryArrayUseNonvolatileRAM.String(), "Non-volatile RAM"},
+		{MemoryArrayUse(0).String(), "0x0"},
+		{MemoryArrayErrorCorrectionCRC.String(), "CRC"},
+		{MemoryArrayErrorCorrection(0x08).String(), "0x8"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("String() = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
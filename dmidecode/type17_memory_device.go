@@ -10,11 +10,13 @@ import (
 	"strings"
 
 	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/registry"
 )
 
 // MemoryDevice is defined in DSP0134 7.18.
 type MemoryDevice struct {
-	smbios.Header                     `smbios:"-"`
+	smbios.Header                     `smbios:"-" json:"Header"`
+	tableVersion                      `smbios:"-"`
 	PhysicalMemoryArrayHandle         uint16                              // 04h
 	MemoryErrorInfoHandle             uint16                              // 06h
 	TotalWidth                        uint16                              // 08h
@@ -26,18 +28,18 @@ type MemoryDevice struct {
 	BankLocator                       string                              // 11h
 	Type                              MemoryDeviceType                    // 12h
 	TypeDetail                        MemoryDeviceTypeDetail              // 13h
-	Speed                             uint16                              // 15h
+	Speed                             uint16                              `smbios:"since=2.3"` // 15h
 	Manufacturer                      string                              // 17h
 	SerialNumber                      string                              // 18h
 	AssetTag                          string                              // 19h
 	PartNumber                        string                              // 1Ah
-	Attributes                        uint8                               // 1Bh
-	ExtendedSize                      uint32                              // 1Ch
+	Attributes                        uint8                               `smbios:"since=2.6"` // 1Bh
+	ExtendedSize                      uint32                              `smbios:"since=2.7"` // 1Ch
 	ConfiguredSpeed                   uint16                              // 20h
-	MinimumVoltage                    uint16                              // 22h
+	MinimumVoltage                    uint16                              `smbios:"since=2.8"` // 22h
 	MaximumVoltage                    uint16                              // 24h
 	ConfiguredVoltage                 uint16                              // 26h
-	Technology                        MemoryDeviceTechnology              // 28h
+	Technology                        MemoryDeviceTechnology              `smbios:"since=3.2"` // 28h
 	OperatingModeCapability           MemoryDeviceOperatingModeCapability // 29h
 	FirmwareVersion                   string                              // 2Bh
 	ModuleManufacturerID              uint16                              // 2Ch
@@ -48,9 +50,18 @@ type MemoryDevice struct {
 	VolatileSize                      uint64                              // 3Ch
 	CacheSize                         uint64                              // 44h
 	LogicalSize                       uint64                              // 4Ch
+	ExtendedSpeed                     uint32                              `smbios:"since=3.3"` // 54h
+	ExtendedConfiguredSpeed           uint32                              // 58h
+	PMIC0ManufacturerID               uint16                              `smbios:"since=3.7"` // 5Ch
+	PMIC0RevisionNumber               uint16                              // 5Eh
+	RCDManufacturerID                 uint16                              // 60h
+	RCDRevisionNumber                 uint16                              // 62h
 }
 
 // MemoryDeviceManufacturer numbers.
+//
+// Deprecated: Use registry.JEP106ID to name ModuleManufacturerID and similar
+// fields, and registry.LookupJEP106 to find the ID of a manufacturer.
 var MemoryDeviceManufacturer = map[string]uint16{
 	"Micron":   0x2C00,
 	"Samsung":  0xCE00,
@@ -81,7 +92,7 @@ func ParseMemoryDevice(t *smbios.Table) (*MemoryDevice, error) {
 	if t.Len() < 0x15 {
 		return nil, fmt.Errorf("%w: memory device table must be at least %d bytes", io.ErrUnexpectedEOF, 0x15)
 	}
-	md := &MemoryDevice{Header: t.Header}
+	md := &MemoryDevice{Header: t.Header, tableVersion: tableVersion{t.Version}}
 	_, err := parseStruct(t, 0 /* off */, false /* complete */, md)
 	if err != nil {
 		return nil, err
@@ -89,6 +100,12 @@ func ParseMemoryDevice(t *smbios.Table) (*MemoryDevice, error) {
 	return md, nil
 }
 
+// Has reports whether the table contains field, the name of a MemoryDevice
+// field. Absent fields are zero.
+func (md *MemoryDevice) Has(field string) bool {
+	return hasField(md, md.Header, md.version, field)
+}
+
 // GetSizeBytes returns size of the memory device, in bytes.
 func (md *MemoryDevice) GetSizeBytes() uint64 {
 	switch md.Size {
@@ -105,17 +122,80 @@ func (md *MemoryDevice) GetSizeBytes() uint64 {
 	}
 }
 
-func (md *MemoryDevice) String() string {
-	ehStr := ""
-	switch md.MemoryErrorInfoHandle {
+// GetSpeed returns the maximum speed of the memory device in MT/s, or 0 if it
+// is unknown. Speeds of 65535 MT/s and above are taken from ExtendedSpeed.
+func (md *MemoryDevice) GetSpeed() uint32 {
+	return memoryDeviceSpeed(md.Speed, md.ExtendedSpeed)
+}
+
+// GetConfiguredSpeed returns the configured speed of the memory device in MT/s,
+// or 0 if it is unknown. Speeds of 65535 MT/s and above are taken from
+// ExtendedConfiguredSpeed.
+func (md *MemoryDevice) GetConfiguredSpeed() uint32 {
+	return memoryDeviceSpeed(md.ConfiguredSpeed, md.ExtendedConfiguredSpeed)
+}
+
+func memoryDeviceSpeed(speed uint16, extended uint32) uint32 {
+	if speed == 0xffff {
+		return extended & 0x7fffffff
+	}
+	return uint32(speed)
+}
+
+// GetMinimumVoltage returns the minimum operating voltage in volts, or 0 if it
+// is unknown.
+func (md *MemoryDevice) GetMinimumVoltage() float32 {
+	return float32(md.MinimumVoltage) / 1000
+}
+
+// GetMaximumVoltage returns the maximum operating voltage in volts, or 0 if it
+// is unknown.
+func (md *MemoryDevice) GetMaximumVoltage() float32 {
+	return float32(md.MaximumVoltage) / 1000
+}
+
+// GetConfiguredVoltage returns the configured voltage in volts, or 0 if it is
+// unknown.
+func (md *MemoryDevice) GetConfiguredVoltage() float32 {
+	return float32(md.ConfiguredVoltage) / 1000
+}
+
+// GetRank returns the rank of the memory device, or 0 if it is unknown.
+func (md *MemoryDevice) GetRank() int {
+	return int(md.Attributes & 0xf)
+}
+
+// GetModuleManufacturer returns the JEP106 name of the module manufacturer, or
+// an empty string if the ID is unknown or not provided.
+func (md *MemoryDevice) GetModuleManufacturer() string {
+	return registry.JEP106ID(md.ModuleManufacturerID).Name()
+}
+
+// GetManufacturer returns the common name of the manufacturer of the memory
+// device, as normalized by registry.NormalizeVendor. If the Manufacturer string
+// is a placeholder, the name is taken from ModuleManufacturerID. It returns an
+// empty string if neither is known.
+func (md *MemoryDevice) GetManufacturer() string {
+	if name := registry.NormalizeVendor(md.Manufacturer); name != "" {
+		return name
+	}
+	return registry.NormalizeVendor(md.GetModuleManufacturer())
+}
+
+// memoryErrorInfoHandleStr formats a handle to memory error information, which
+// has two reserved values.
+func memoryErrorInfoHandleStr(h uint16) string {
+	switch h {
 	case 0xffff:
-		ehStr = "No Error"
+		return "No Error"
 	case 0xfffe:
-		ehStr = "Not Provided"
+		return "Not Provided"
 	default:
-		ehStr = fmt.Sprintf("0x%04X", md.MemoryErrorInfoHandle)
+		return fmt.Sprintf("0x%04X", h)
 	}
+}
 
+func (md *MemoryDevice) String() string {
 	bitWidthStr := func(v uint16) string {
 		if v == 0 || v == 0xffff {
 			return "Unknown"
@@ -133,8 +213,8 @@ func (md *MemoryDevice) String() string {
 		setStr = fmt.Sprintf("%d", md.DeviceSet)
 	}
 
-	speedStr := func(v uint16) string {
-		if v == 0 || v == 0xffff {
+	speedStr := func(v uint32) string {
+		if v == 0 {
 			return "Unknown"
 		}
 		return fmt.Sprintf("%d MT/s", v)
@@ -148,7 +228,7 @@ func (md *MemoryDevice) String() string {
 	lines := []string{
 		md.Header.String(),
 		fmt.Sprintf("Array Handle: 0x%04X", md.PhysicalMemoryArrayHandle),
-		fmt.Sprintf("Error Information Handle: %s", ehStr),
+		fmt.Sprintf("Error Information Handle: %s", memoryErrorInfoHandleStr(md.MemoryErrorInfoHandle)),
 		fmt.Sprintf("Total Width: %s", bitWidthStr(md.TotalWidth)),
 		fmt.Sprintf("Data Width: %s", bitWidthStr(md.DataWidth)),
 		fmt.Sprintf("Size: %s", moduleSizeStr),
@@ -159,26 +239,28 @@ func (md *MemoryDevice) String() string {
 		fmt.Sprintf("Type: %s", md.Type),
 		fmt.Sprintf("Type Detail: %s", md.TypeDetail),
 	}
-	if md.Header.Length > 0x15 {
+	if md.Has("Speed") {
+		lines = append(lines, fmt.Sprintf("Speed: %s", speedStr(md.GetSpeed())))
+	}
+	if md.Has("PartNumber") {
 		lines = append(lines,
-			fmt.Sprintf("Speed: %s", speedStr(md.Speed)),
 			fmt.Sprintf("Manufacturer: %s", smbiosStr(md.Manufacturer)),
 			fmt.Sprintf("Serial Number: %s", smbiosStr(md.SerialNumber)),
 			fmt.Sprintf("Asset Tag: %s", smbiosStr(md.AssetTag)),
 			fmt.Sprintf("Part Number: %s", smbiosStr(md.PartNumber)),
 		)
 	}
-	if md.Header.Length > 0x1b {
+	if md.Has("Attributes") {
 		rankStr := "Unknown"
-		if md.Attributes&0xf != 0 {
-			rankStr = fmt.Sprintf("%d", md.Attributes&0xf)
+		if md.GetRank() != 0 {
+			rankStr = fmt.Sprintf("%d", md.GetRank())
 		}
 		lines = append(lines, fmt.Sprintf("Rank: %s", rankStr))
 	}
-	if md.Header.Length > 0x1c {
-		lines = append(lines, fmt.Sprintf("Configured Memory Speed: %s", speedStr(md.ConfiguredSpeed)))
+	if md.Has("ConfiguredSpeed") {
+		lines = append(lines, fmt.Sprintf("Configured Memory Speed: %s", speedStr(md.GetConfiguredSpeed())))
 	}
-	if md.Header.Length > 0x22 {
+	if md.Has("ConfiguredVoltage") {
 		voltageStr := func(v uint16) string {
 			switch {
 			case v == 0:
@@ -195,19 +277,24 @@ func (md *MemoryDevice) String() string {
 			fmt.Sprintf("Configured Voltage: %s", voltageStr(md.ConfiguredVoltage)),
 		)
 	}
-	if md.Header.Length > 0x28 {
-		manufacturerIDStr := func(id uint16) string {
-			if id == 0 {
-				return "Unknown"
-			}
-			return fmt.Sprintf("Bank %d, Hex 0x%02X", (id&0x7F)+1, id>>8)
-		}
+	if md.Has("SubsystemControllerProductID") {
 		productIDStr := func(v uint16) string {
 			if v == 0 {
 				return "Unknown"
 			}
 			return fmt.Sprintf("0x%04X", v)
 		}
+		lines = append(lines,
+			fmt.Sprintf("Memory Technology: %s", md.Technology),
+			fmt.Sprintf("Memory Operating Mode Capability: %s", md.OperatingModeCapability),
+			fmt.Sprintf("Firmware Version: %s", smbiosStr(md.FirmwareVersion)),
+			fmt.Sprintf("Module Manufacturer ID: %s", registry.JEP106ID(md.ModuleManufacturerID)),
+			fmt.Sprintf("Module Product ID: %s", productIDStr(md.ModuleProductID)),
+			fmt.Sprintf("Memory Subsystem Controller Manufacturer ID: %s", registry.JEP106ID(md.SubsystemControllerManufacturerID)),
+			fmt.Sprintf("Memory Subsystem Controller Product ID: %s", productIDStr(md.SubsystemControllerProductID)),
+		)
+	}
+	if md.Has("LogicalSize") {
 		sizeStr := func(v uint64) string {
 			switch v {
 			case 0:
@@ -219,19 +306,30 @@ func (md *MemoryDevice) String() string {
 			}
 		}
 		lines = append(lines,
-			fmt.Sprintf("Memory Technology: %s", md.Technology),
-			fmt.Sprintf("Memory Operating Mode Capability: %s", md.OperatingModeCapability),
-			fmt.Sprintf("Firmware Version: %s", smbiosStr(md.FirmwareVersion)),
-			fmt.Sprintf("Module Manufacturer ID: %s", manufacturerIDStr(md.ModuleManufacturerID)),
-			fmt.Sprintf("Module Product ID: %s", productIDStr(md.ModuleProductID)),
-			fmt.Sprintf("Memory Subsystem Controller Manufacturer ID: %s", manufacturerIDStr(md.SubsystemControllerManufacturerID)),
-			fmt.Sprintf("Memory Subsystem Controller Product ID: %s", productIDStr(md.SubsystemControllerProductID)),
 			fmt.Sprintf("Non-Volatile Size: %s", sizeStr(md.NonvolatileSize)),
 			fmt.Sprintf("Volatile Size: %s", sizeStr(md.VolatileSize)),
 			fmt.Sprintf("Cache Size: %s", sizeStr(md.CacheSize)),
 			fmt.Sprintf("Logical Size: %s", sizeStr(md.LogicalSize)),
 		)
 	}
+	if md.Has("RCDRevisionNumber") {
+		revisionStr := func(v uint16) string {
+			switch {
+			case v == 0xff00:
+				return "Unknown"
+			case v&0xff00 == 0 && (md.Type == MemoryDeviceTypeDDR5 || md.Type == MemoryDeviceTypeLPDDR5):
+				return fmt.Sprintf("%X.%X", v>>4&0xf, v&0xf)
+			default:
+				return fmt.Sprintf("0x%04X", v)
+			}
+		}
+		lines = append(lines,
+			fmt.Sprintf("PMIC0 Manufacturer ID: %s", registry.JEP106ID(md.PMIC0ManufacturerID)),
+			fmt.Sprintf("PMIC0 Revision Number: %s", revisionStr(md.PMIC0RevisionNumber)),
+			fmt.Sprintf("RCD Manufacturer ID: %s", registry.JEP106ID(md.RCDManufacturerID)),
+			fmt.Sprintf("RCD Revision Number: %s", revisionStr(md.RCDRevisionNumber)),
+		)
+	}
 	return strings.Join(lines, "\n\t")
 }
 
@@ -314,7 +412,11 @@ const (
 	MemoryDeviceTypeLPDDR3                   MemoryDeviceType = 0x1d // LPDDR3
 	MemoryDeviceTypeLPDDR4                   MemoryDeviceType = 0x1e // LPDDR4
 	MemoryDeviceTypeLogicalNonvolatileDevice MemoryDeviceType = 0x1f // Logical non-volatile device
+	MemoryDeviceTypeHBM                      MemoryDeviceType = 0x20 // HBM
+	MemoryDeviceTypeHBM2                     MemoryDeviceType = 0x21 // HBM2
 	MemoryDeviceTypeDDR5                     MemoryDeviceType = 0x22 // DDR5
+	MemoryDeviceTypeLPDDR5                   MemoryDeviceType = 0x23 // LPDDR5
+	MemoryDeviceTypeHBM3                     MemoryDeviceType = 0x24 // HBM3
 )
 
 func (v MemoryDeviceType) String() string {
@@ -347,7 +449,11 @@ func (v MemoryDeviceType) String() string {
 		MemoryDeviceTypeLPDDR3:                   "LPDDR3",
 		MemoryDeviceTypeLPDDR4:                   "LPDDR4",
 		MemoryDeviceTypeLogicalNonvolatileDevice: "Logical non-volatile device",
+		MemoryDeviceTypeHBM:                      "HBM",
+		MemoryDeviceTypeHBM2:                     "HBM2",
 		MemoryDeviceTypeDDR5:                     "DDR5",
+		MemoryDeviceTypeLPDDR5:                   "LPDDR5",
+		MemoryDeviceTypeHBM3:                     "HBM3",
 	}
 	if name, ok := names[v]; ok {
 		return name
@@ -377,59 +483,47 @@ const (
 	MemoryDeviceTypeDetailLRDIMM                 MemoryDeviceTypeDetail = 1 << 15 // LRDIMM
 )
 
+var memoryDeviceTypeDetailStr = map[MemoryDeviceTypeDetail]string{
+	MemoryDeviceTypeDetailOther:                  "Other",
+	MemoryDeviceTypeDetailUnknown:                "Unknown",
+	MemoryDeviceTypeDetailFastpaged:              "Fast-paged",
+	MemoryDeviceTypeDetailStaticColumn:           "Static column",
+	MemoryDeviceTypeDetailPseudostatic:           "Pseudo-static",
+	MemoryDeviceTypeDetailRAMBUS:                 "RAMBUS",
+	MemoryDeviceTypeDetailSynchronous:            "Synchronous",
+	MemoryDeviceTypeDetailCMOS:                   "CMOS",
+	MemoryDeviceTypeDetailEDO:                    "EDO",
+	MemoryDeviceTypeDetailWindowDRAM:             "Window DRAM",
+	MemoryDeviceTypeDetailCacheDRAM:              "Cache DRAM",
+	MemoryDeviceTypeDetailNonvolatile:            "Non-volatile",
+	MemoryDeviceTypeDetailRegisteredBuffered:     "Registered (Buffered)",
+	MemoryDeviceTypeDetailUnbufferedUnregistered: "Unbuffered (Unregistered)",
+	MemoryDeviceTypeDetailLRDIMM:                 "LRDIMM",
+}
+
 func (v MemoryDeviceTypeDetail) String() string {
 	if v&0xfffe == 0 {
 		return "None"
 	}
 	var lines []string
-	if v&MemoryDeviceTypeDetailOther != 0 {
-		lines = append(lines, "Other")
-	}
-	if v&MemoryDeviceTypeDetailUnknown != 0 {
-		lines = append(lines, "Unknown")
-	}
-	if v&MemoryDeviceTypeDetailFastpaged != 0 {
-		lines = append(lines, "Fast-paged")
-	}
-	if v&MemoryDeviceTypeDetailStaticColumn != 0 {
-		lines = append(lines, "Static column")
-	}
-	if v&MemoryDeviceTypeDetailPseudostatic != 0 {
-		lines = append(lines, "Pseudo-static")
-	}
-	if v&MemoryDeviceTypeDetailRAMBUS != 0 {
-		lines = append(lines, "RAMBUS")
-	}
-	if v&MemoryDeviceTypeDetailSynchronous != 0 {
-		lines = append(lines, "Synchronous")
-	}
-	if v&MemoryDeviceTypeDetailCMOS != 0 {
-		lines = append(lines, "CMOS")
-	}
-	if v&MemoryDeviceTypeDetailEDO != 0 {
-		lines = append(lines, "EDO")
-	}
-	if v&MemoryDeviceTypeDetailWindowDRAM != 0 {
-		lines = append(lines, "Window DRAM")
-	}
-	if v&MemoryDeviceTypeDetailCacheDRAM != 0 {
-		lines = append(lines, "Cache DRAM")
-	}
-	if v&MemoryDeviceTypeDetailNonvolatile != 0 {
-		lines = append(lines, "Non-volatile")
-	}
-	if v&MemoryDeviceTypeDetailRegisteredBuffered != 0 {
-		lines = append(lines, "Registered (Buffered)")
-	}
-	if v&MemoryDeviceTypeDetailUnbufferedUnregistered != 0 {
-		lines = append(lines, "Unbuffered (Unregistered)")
-	}
-	if v&MemoryDeviceTypeDetailLRDIMM != 0 {
-		lines = append(lines, "LRDIMM")
+	for i := 1; i < 16; i++ {
+		if v&(1<<i) != 0 {
+			lines = append(lines, memoryDeviceTypeDetailStr[1<<i])
+		}
 	}
 	return strings.Join(lines, " ")
 }
 
+// MarshalJSON implements json.Marshaler.
+func (v MemoryDeviceTypeDetail) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, memoryDeviceTypeDetailStr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *MemoryDeviceTypeDetail) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, memoryDeviceTypeDetailStr)
+}
+
 // MemoryDeviceTechnology is defined in DSP0134 7.18.6.
 type MemoryDeviceTechnology uint8
 
@@ -472,25 +566,33 @@ const (
 	MemoryDeviceOperatingModeCapabilityBlockaccessiblePersistentMemory MemoryDeviceOperatingModeCapability = 1 << 5 // Block-accessible persistent memory
 )
 
+var memoryDeviceOperatingModeCapabilityStr = map[MemoryDeviceOperatingModeCapability]string{
+	MemoryDeviceOperatingModeCapabilityOther:                           "Other",
+	MemoryDeviceOperatingModeCapabilityUnknown:                         "Unknown",
+	MemoryDeviceOperatingModeCapabilityVolatileMemory:                  "Volatile memory",
+	MemoryDeviceOperatingModeCapabilityByteaccessiblePersistentMemory:  "Byte-accessible persistent memory",
+	MemoryDeviceOperatingModeCapabilityBlockaccessiblePersistentMemory: "Block-accessible persistent memory",
+}
+
 func (v MemoryDeviceOperatingModeCapability) String() string {
 	if v&0xfffe == 0 {
 		return "None"
 	}
 	var lines []string
-	if v&MemoryDeviceOperatingModeCapabilityOther != 0 {
-		lines = append(lines, "Other")
-	}
-	if v&MemoryDeviceOperatingModeCapabilityUnknown != 0 {
-		lines = append(lines, "Unknown")
-	}
-	if v&MemoryDeviceOperatingModeCapabilityVolatileMemory != 0 {
-		lines = append(lines, "Volatile memory")
-	}
-	if v&MemoryDeviceOperatingModeCapabilityByteaccessiblePersistentMemory != 0 {
-		lines = append(lines, "Byte-accessible persistent memory")
-	}
-	if v&MemoryDeviceOperatingModeCapabilityBlockaccessiblePersistentMemory != 0 {
-		lines = append(lines, "Block-accessible persistent memory")
+	for i := 1; i < 6; i++ {
+		if v&(1<<i) != 0 {
+			lines = append(lines, memoryDeviceOperatingModeCapabilityStr[1<<i])
+		}
 	}
 	return strings.Join(lines, " ")
 }
+
+// MarshalJSON implements json.Marshaler.
+func (v MemoryDeviceOperatingModeCapability) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, memoryDeviceOperatingModeCapabilityStr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *MemoryDeviceOperatingModeCapability) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, memoryDeviceOperatingModeCapabilityStr)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/registry"
 )
 
 func TestGetSizeBytes(t *testing.T) {
@@ -298,6 +299,35 @@ BIOS Information
 				PartNumber:            "1",
 			},
 			want: `Handle 0x0000, DMI type 0, 22 bytes
+BIOS Information
+	Array Handle: 0x0000
+	Error Information Handle: Not Provided
+	Total Width: Unknown
+	Data Width: Unknown
+	Size: No Module Installed
+	Form Factor: 0x0
+	Set: 254
+	Locator: Not Specified
+	Bank Locator: Not Specified
+	Type: 0x0
+	Type Detail: None`,
+		},
+		{
+			name: "Metadata, 27 bytes",
+			MemDev: MemoryDevice{
+				Header: smbios.Header{
+					Length: 27,
+				},
+				MemoryErrorInfoHandle: 0xfffe,
+				DeviceSet:             0xfe,
+				Size:                  0x0,
+				Speed:                 0x0100,
+				Manufacturer:          "Google",
+				SerialNumber:          "DEADBEEF",
+				AssetTag:              "#354432",
+				PartNumber:            "1",
+			},
+			want: `Handle 0x0000, DMI type 0, 27 bytes
 BIOS Information
 	Array Handle: 0x0000
 	Error Information Handle: Not Provided
@@ -337,6 +367,47 @@ BIOS Information
 				ConfiguredVoltage:     0x2000,
 			},
 			want: `Handle 0x0000, DMI type 0, 36 bytes
+BIOS Information
+	Array Handle: 0x0000
+	Error Information Handle: Not Provided
+	Total Width: Unknown
+	Data Width: Unknown
+	Size: No Module Installed
+	Form Factor: 0x0
+	Set: 254
+	Locator: Not Specified
+	Bank Locator: Not Specified
+	Type: 0x0
+	Type Detail: None
+	Speed: 256 MT/s
+	Manufacturer: Google
+	Serial Number: DEADBEEF
+	Asset Tag: #354432
+	Part Number: 1
+	Rank: 13
+	Configured Memory Speed: 16 MT/s`,
+		},
+		{
+			name: "Voltage and Speed, 40 bytes",
+			MemDev: MemoryDevice{
+				Header: smbios.Header{
+					Length: 40,
+				},
+				MemoryErrorInfoHandle: 0xfffe,
+				DeviceSet:             0xfe,
+				Size:                  0x0,
+				Speed:                 0x0100,
+				Manufacturer:          "Google",
+				SerialNumber:          "DEADBEEF",
+				AssetTag:              "#354432",
+				PartNumber:            "1",
+				Attributes:            0x0D,
+				ConfiguredSpeed:       0x0010,
+				MinimumVoltage:        0x0,
+				MaximumVoltage:        0x0064,
+				ConfiguredVoltage:     0x2000,
+			},
+			want: `Handle 0x0000, DMI type 0, 40 bytes
 BIOS Information
 	Array Handle: 0x0000
 	Error Information Handle: Not Provided
@@ -391,6 +462,60 @@ BIOS Information
 				LogicalSize:                       0x00001000,
 			},
 			want: `Handle 0x0000, DMI type 0, 42 bytes
+BIOS Information
+	Array Handle: 0x0000
+	Error Information Handle: Not Provided
+	Total Width: Unknown
+	Data Width: Unknown
+	Size: No Module Installed
+	Form Factor: 0x0
+	Set: 254
+	Locator: Not Specified
+	Bank Locator: Not Specified
+	Type: 0x0
+	Type Detail: None
+	Speed: 256 MT/s
+	Manufacturer: Google
+	Serial Number: DEADBEEF
+	Asset Tag: #354432
+	Part Number: 1
+	Rank: 13
+	Configured Memory Speed: 16 MT/s
+	Minimum Voltage: Unknown
+	Maximum Voltage: 0.1 V
+	Configured Voltage: 8.192 V`,
+		},
+		{
+			name: "Sizes, 84 bytes",
+			MemDev: MemoryDevice{
+				Header: smbios.Header{
+					Length: 84,
+				},
+				MemoryErrorInfoHandle:             0xfffe,
+				DeviceSet:                         0xfe,
+				Size:                              0x0,
+				Speed:                             0x0100,
+				Manufacturer:                      "Google",
+				SerialNumber:                      "DEADBEEF",
+				AssetTag:                          "#354432",
+				PartNumber:                        "1",
+				Attributes:                        0x0D,
+				ConfiguredSpeed:                   0x0010,
+				MinimumVoltage:                    0x0,
+				MaximumVoltage:                    0x0064,
+				ConfiguredVoltage:                 0x2000,
+				Technology:                        0x1,
+				OperatingModeCapability:           0x1,
+				FirmwareVersion:                   "2.3",
+				ModuleManufacturerID:              0x1234,
+				ModuleProductID:                   0x1234,
+				SubsystemControllerManufacturerID: 0x11,
+				SubsystemControllerProductID:      0x22,
+				NonvolatileSize:                   0x00100000,
+				CacheSize:                         0x00001000,
+				LogicalSize:                       0x00001000,
+			},
+			want: `Handle 0x0000, DMI type 0, 84 bytes
 BIOS Information
 	Array Handle: 0x0000
 	Error Information Handle: Not Provided
@@ -425,6 +550,68 @@ BIOS Information
 	Cache Size: 4 kB
 	Logical Size: 4 kB`,
 		},
+		{
+			name: "PMIC and RCD",
+			MemDev: MemoryDevice{
+				Header: smbios.Header{
+					Length: 100,
+				},
+				MemoryErrorInfoHandle:   0xffff,
+				Type:                    MemoryDeviceTypeDDR5,
+				Speed:                   0xffff,
+				ConfiguredSpeed:         4800,
+				Technology:              0x3,
+				ModuleManufacturerID:    0xce00,
+				NonvolatileSize:         0,
+				VolatileSize:            0xffffffffffffffff,
+				CacheSize:               0,
+				LogicalSize:             0,
+				ExtendedSpeed:           70000,
+				ExtendedConfiguredSpeed: 0x100,
+				PMIC0ManufacturerID:     0x3206,
+				PMIC0RevisionNumber:     0x0012,
+				RCDManufacturerID:       0xb380,
+				RCDRevisionNumber:       0xff00,
+			},
+			want: `Handle 0x0000, DMI type 0, 100 bytes
+BIOS Information
+	Array Handle: 0x0000
+	Error Information Handle: No Error
+	Total Width: Unknown
+	Data Width: Unknown
+	Size: No Module Installed
+	Form Factor: 0x0
+	Set: None
+	Locator: Not Specified
+	Bank Locator: Not Specified
+	Type: DDR5
+	Type Detail: None
+	Speed: 70000 MT/s
+	Manufacturer: Not Specified
+	Serial Number: Not Specified
+	Asset Tag: Not Specified
+	Part Number: Not Specified
+	Rank: Unknown
+	Configured Memory Speed: 4800 MT/s
+	Minimum Voltage: Unknown
+	Maximum Voltage: Unknown
+	Configured Voltage: Unknown
+	Memory Technology: DRAM
+	Memory Operating Mode Capability: None
+	Firmware Version: Not Specified
+	Module Manufacturer ID: Bank 1, Hex 0xCE
+	Module Product ID: Unknown
+	Memory Subsystem Controller Manufacturer ID: Unknown
+	Memory Subsystem Controller Product ID: Unknown
+	Non-Volatile Size: None
+	Volatile Size: Unknown
+	Cache Size: None
+	Logical Size: None
+	PMIC0 Manufacturer ID: Bank 7, Hex 0x32
+	PMIC0 Revision Number: 1.2
+	RCD Manufacturer ID: Bank 1, Hex 0xB3
+	RCD Revision Number: Unknown`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -512,3 +699,98 @@ func TestParseMemoryDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestMemoryDeviceHelpers(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		md                 MemoryDevice
+		speed              uint32
+		configuredSpeed    uint32
+		minVoltage         float32
+		configuredVoltage  float32
+		rank               int
+		moduleManufacturer string
+		manufacturer       string
+	}{
+		{
+			name: "Unknown",
+			md:   MemoryDevice{},
+		},
+		{
+			name: "Regular",
+			md: MemoryDevice{
+				Speed:                2400,
+				ConfiguredSpeed:      2133,
+				Attributes:           0x02,
+				MinimumVoltage:       1200,
+				ConfiguredVoltage:    1350,
+				ModuleManufacturerID: 0x2c80,
+			},
+			speed:              2400,
+			configuredSpeed:    2133,
+			minVoltage:         1.2,
+			configuredVoltage:  1.35,
+			rank:               2,
+			moduleManufacturer: "Micron Technology",
+			manufacturer:       "Micron",
+		},
+		{
+			name: "Extended",
+			md: MemoryDevice{
+				Speed:                   0xffff,
+				ConfiguredSpeed:         0xffff,
+				ExtendedSpeed:           0x80010000,
+				ExtendedConfiguredSpeed: 0x10001,
+				Attributes:              0xf4,
+				Manufacturer:            "859B",
+				ModuleManufacturerID:    0x9801,
+			},
+			speed:              0x10000,
+			configuredSpeed:    0x10001,
+			rank:               4,
+			moduleManufacturer: "Kingston",
+			manufacturer:       "Crucial",
+		},
+		{
+			name: "Unknown manufacturer",
+			md: MemoryDevice{
+				Manufacturer:         "Undefined",
+				ModuleManufacturerID: 0x0010,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.md.GetSpeed(); got != tt.speed {
+				t.Errorf("GetSpeed() = %d, want %d", got, tt.speed)
+			}
+			if got := tt.md.GetConfiguredSpeed(); got != tt.configuredSpeed {
+				t.Errorf("GetConfiguredSpeed() = %d, want %d", got, tt.configuredSpeed)
+			}
+			if got := tt.md.GetMinimumVoltage(); got != tt.minVoltage {
+				t.Errorf("GetMinimumVoltage() = %g, want %g", got, tt.minVoltage)
+			}
+			if got := tt.md.GetConfiguredVoltage(); got != tt.configuredVoltage {
+				t.Errorf("GetConfiguredVoltage() = %g, want %g", got, tt.configuredVoltage)
+			}
+			if got := tt.md.GetRank(); got != tt.rank {
+				t.Errorf("GetRank() = %d, want %d", got, tt.rank)
+			}
+			if got := tt.md.GetModuleManufacturer(); got != tt.moduleManufacturer {
+				t.Errorf("GetModuleManufacturer() = %q, want %q", got, tt.moduleManufacturer)
+			}
+			if got := tt.md.GetManufacturer(); got != tt.manufacturer {
+				t.Errorf("GetManufacturer() = %q, want %q", got, tt.manufacturer)
+			}
+		})
+	}
+}
+
+func TestMemoryDeviceManufacturer(t *testing.T) {
+	// The values omit the parity bit of the continuation count.
+	for name, v := range MemoryDeviceManufacturer {
+		id := registry.NewJEP106ID(int(v&0xff)+1, uint8(v>>8))
+		if id.Name() == "" {
+			t.Errorf("MemoryDeviceManufacturer[%q] = %#04x, not a known JEP106 ID", name, v)
+		}
+	}
+}
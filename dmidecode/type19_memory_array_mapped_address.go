@@ -0,0 +1,101 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/u-root/smbios"
+)
+
+// MemoryArrayMappedAddress is defined in DSP0134 7.20.
+type MemoryArrayMappedAddress struct {
+	smbios.Header             `smbios:"-" json:"Header"`
+	tableVersion              `smbios:"-"`
+	StartingAddress           uint32 // 04h
+	EndingAddress             uint32 // 08h
+	PhysicalMemoryArrayHandle uint16 // 0Ch
+	PartitionWidth            uint8  // 0Eh
+	ExtendedStartingAddress   uint64 `smbios:"since=2.7"` // 0Fh
+	ExtendedEndingAddress     uint64 // 17h
+}
+
+// ParseMemoryArrayMappedAddress parses a generic smbios.Table into MemoryArrayMappedAddress.
+func ParseMemoryArrayMappedAddress(t *smbios.Table) (*MemoryArrayMappedAddress, error) {
+	if t.Type != smbios.TableTypeMemoryArrayMappedAddress {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedTableType, t.Type)
+	}
+	if t.Len() < 0xf {
+		return nil, fmt.Errorf("%w: memory array mapped address table must be at least %d bytes", io.ErrUnexpectedEOF, 0xf)
+	}
+	mam := &MemoryArrayMappedAddress{Header: t.Header, tableVersion: tableVersion{t.Version}}
+	if _, err := parseStruct(t, 0 /* off */, false /* complete */, mam); err != nil {
+		return nil, err
+	}
+	return mam, nil
+}
+
+// Has reports whether the table contains field, the name of a
+// MemoryArrayMappedAddress field. Absent fields are zero.
+func (mam *MemoryArrayMappedAddress) Has(field string) bool {
+	return hasField(mam, mam.Header, mam.version, field)
+}
+
+// extended reports whether the range is given by the extended addresses.
+func (mam *MemoryArrayMappedAddress) extended() bool {
+	return mam.StartingAddress == 0xffffffff && mam.Has("ExtendedEndingAddress")
+}
+
+// GetStartingAddress returns the physical address of the first byte of the
+// range, taken from the appropriate field.
+func (mam *MemoryArrayMappedAddress) GetStartingAddress() uint64 {
+	if mam.extended() {
+		return mam.ExtendedStartingAddress
+	}
+	return uint64(mam.StartingAddress) << 10
+}
+
+// GetEndingAddress returns the physical address of the last byte of the range,
+// taken from the appropriate field.
+func (mam *MemoryArrayMappedAddress) GetEndingAddress() uint64 {
+	if mam.extended() {
+		return mam.ExtendedEndingAddress
+	}
+	return uint64(mam.EndingAddress)<<10 + 0x3ff
+}
+
+// GetSizeBytes returns the size of the range, in bytes. It is 0 if the range is
+// invalid.
+func (mam *MemoryArrayMappedAddress) GetSizeBytes() uint64 {
+	start, end := mam.GetStartingAddress(), mam.GetEndingAddress()
+	if end < start {
+		return 0
+	}
+	return end - start + 1
+}
+
+func (mam *MemoryArrayMappedAddress) String() string {
+	addrStr := func(a uint64) string {
+		if mam.extended() {
+			return fmt.Sprintf("0x%016X", a)
+		}
+		return fmt.Sprintf("0x%011X", a)
+	}
+	sizeStr := "Invalid"
+	if s := mam.GetSizeBytes(); s > 0 {
+		sizeStr = kmgt(s)
+	}
+	lines := []string{
+		mam.Header.String(),
+		fmt.Sprintf("Starting Address: %s", addrStr(mam.GetStartingAddress())),
+		fmt.Sprintf("Ending Address: %s", addrStr(mam.GetEndingAddress())),
+		fmt.Sprintf("Range Size: %s", sizeStr),
+		fmt.Sprintf("Physical Array Handle: 0x%04X", mam.PhysicalMemoryArrayHandle),
+		fmt.Sprintf("Partition Width: %d", mam.PartitionWidth),
+	}
+	return strings.Join(lines, "\n\t")
+}
@@ -0,0 +1,99 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func mappedAddressTable(handle, array uint16, startKB, endKB uint32, extStart, extEnd uint64) *smbios.Table {
+	data := make([]byte, 0x1b)
+	binary.LittleEndian.PutUint32(data[0x00:], startKB)
+	binary.LittleEndian.PutUint32(data[0x04:], endKB)
+	binary.LittleEndian.PutUint16(data[0x08:], array)
+	data[0x0a] = 2
+	binary.LittleEndian.PutUint64(data[0x0b:], extStart)
+	binary.LittleEndian.PutUint64(data[0x13:], extEnd)
+	return handleTable(smbios.TableTypeMemoryArrayMappedAddress, handle, data)
+}
+
+func TestParseMemoryArrayMappedAddress(t *testing.T) {
+	short := mappedAddressTable(0x40, 0x10, 0x00400000, 0x005fffff, 0, 0)
+	short.Length, short.Data = 0x0f, short.Data[:0x0b]
+
+	for _, tt := range []struct {
+		name      string
+		table     *smbios.Table
+		wantStart uint64
+		wantEnd   uint64
+		wantSize  uint64
+		want      string
+	}{
+		{
+			name:      "Addresses",
+			table:     short,
+			wantStart: 4 << 30,
+			wantEnd:   6<<30 - 1,
+			wantSize:  2 << 30,
+			want: `Handle 0x0040, DMI type 19, 15 bytes
+Memory Array Mapped Address
+	Starting Address: 0x00100000000
+	Ending Address: 0x0017FFFFFFF
+	Range Size: 2 GB
+	Physical Array Handle: 0x0010
+	Partition Width: 2`,
+		},
+		{
+			name:      "Extended Addresses",
+			table:     mappedAddressTable(0x40, 0x10, 0xffffffff, 0xffffffff, 4<<40, 6<<40-1),
+			wantStart: 4 << 40,
+			wantEnd:   6<<40 - 1,
+			wantSize:  2 << 40,
+			want: `Handle 0x0040, DMI type 19, 31 bytes
+Memory Array Mapped Address
+	Starting Address: 0x0000040000000000
+	Ending Address: 0x000005FFFFFFFFFF
+	Range Size: 2 TB
+	Physical Array Handle: 0x0010
+	Partition Width: 2`,
+		},
+		{
+			name:      "Invalid",
+			table:     mappedAddressTable(0x40, 0x10, 0x1000, 0x0fff, 0, 0),
+			wantStart: 0x400000,
+			wantEnd:   0x3fffff,
+			wantSize:  0,
+			want: `Handle 0x0040, DMI type 19, 31 bytes
+Memory Array Mapped Address
+	Starting Address: 0x00000400000
+	Ending Address: 0x000003FFFFF
+	Range Size: Invalid
+	Physical Array Handle: 0x0010
+	Partition Width: 2`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mam, err := ParseMemoryArrayMappedAddress(tt.table)
+			if err != nil {
+				t.Fatalf("ParseMemoryArrayMappedAddress() = %v", err)
+			}
+			if got := mam.GetStartingAddress(); got != tt.wantStart {
+				t.Errorf("GetStartingAddress() = %#x, want %#x", got, tt.wantStart)
+			}
+			if got := mam.GetEndingAddress(); got != tt.wantEnd {
+				t.Errorf("GetEndingAddress() = %#x, want %#x", got, tt.wantEnd)
+			}
+			if got := mam.GetSizeBytes(); got != tt.wantSize {
+				t.Errorf("GetSizeBytes() = %#x, want %#x", got, tt.wantSize)
+			}
+			if got := mam.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
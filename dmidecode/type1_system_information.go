@@ -6,6 +6,8 @@ package dmidecode
 
 import (
 	"bytes"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -15,14 +17,15 @@ import (
 
 // SystemInfo is defined in DSP0134 7.2.
 type SystemInfo struct {
-	smbios.Header `smbios:"-"`
+	smbios.Header `smbios:"-" json:"Header"`
+	tableVersion  `smbios:"-"`
 	Manufacturer  string     // 04h
 	ProductName   string     // 05h
 	Version       string     // 06h
 	SerialNumber  string     // 07h
-	UUID          UUID       // 08h
+	UUID          UUID       `smbios:"since=2.1"` // 08h
 	WakeupType    WakeupType // 18h
-	SKUNumber     string     // 19h
+	SKUNumber     string     `smbios:"since=2.4"` // 19h
 	Family        string     // 1Ah
 }
 
@@ -34,13 +37,57 @@ func ParseSystemInfo(t *smbios.Table) (*SystemInfo, error) {
 	if t.Len() < 8 {
 		return nil, fmt.Errorf("%w: system info table must be at least %d bytes", io.ErrUnexpectedEOF, 8)
 	}
-	si := &SystemInfo{Header: t.Header}
+	si := &SystemInfo{Header: t.Header, tableVersion: tableVersion{t.Version}}
 	if _, err := parseStruct(t, 0 /* off */, false /* complete */, si); err != nil {
 		return nil, err
 	}
 	return si, nil
 }
 
+// Has reports whether the table contains field, the name of a SystemInfo field.
+// Absent fields are zero.
+func (si *SystemInfo) Has(field string) bool {
+	return hasField(si, si.Header, si.version, field)
+}
+
+// UUIDString returns the UUID as dmidecode(8) prints it. SMBIOS versions
+// before 2.6 did not define the byte order of the UUID, their UUIDs are given
+// with all bytes in table order.
+func (si *SystemInfo) UUIDString() string {
+	return si.uuid().String()
+}
+
+// uuid returns the UUID in the byte order of DSP0134 7.2.1 for the version
+// of si, see UUIDString.
+func (si *SystemInfo) uuid() UUID {
+	if !si.since(2, 6) {
+		return si.UUID.legacyOrder()
+	}
+	return si.UUID
+}
+
+// systemInfoJSON is SystemInfo without its JSON methods.
+type systemInfoJSON SystemInfo
+
+// MarshalJSON implements json.Marshaler. The UUID is given as UUIDString
+// gives it.
+func (si *SystemInfo) MarshalJSON() ([]byte, error) {
+	sj := systemInfoJSON(*si)
+	sj.UUID = si.uuid()
+	return json.Marshal(&sj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The UUID is read in the byte
+// order of the SMBIOS version of si, see MarshalJSON.
+func (si *SystemInfo) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*systemInfoJSON)(si)); err != nil {
+		return err
+	}
+	// Reversing the first three fields is its own inverse.
+	si.UUID = si.uuid()
+	return nil
+}
+
 // ParseField parses UUD field within a table.
 func (u *UUID) ParseField(t *smbios.Table, off int) (int, error) {
 	ub, err := t.GetBytesAt(off, 16)
@@ -58,10 +105,18 @@ func (si *SystemInfo) String() string {
 		fmt.Sprintf("Product Name: %s", smbiosStr(si.ProductName)),
 		fmt.Sprintf("Version: %s", smbiosStr(si.Version)),
 		fmt.Sprintf("Serial Number: %s", smbiosStr(si.SerialNumber)),
-		fmt.Sprintf("UUID: %s", si.UUID),
-		fmt.Sprintf("Wake-up Type: %s", si.WakeupType),
-		fmt.Sprintf("SKU Number: %s", smbiosStr(si.SKUNumber)),
-		fmt.Sprintf("Family: %s", smbiosStr(si.Family)),
+	}
+	if si.since(2, 1) {
+		lines = append(lines,
+			fmt.Sprintf("UUID: %s", si.UUIDString()),
+			fmt.Sprintf("Wake-up Type: %s", si.WakeupType),
+		)
+	}
+	if si.since(2, 4) {
+		lines = append(lines,
+			fmt.Sprintf("SKU Number: %s", smbiosStr(si.SKUNumber)),
+			fmt.Sprintf("Family: %s", smbiosStr(si.Family)),
+		)
 	}
 	return strings.Join(lines, "\n\t")
 }
@@ -78,7 +133,7 @@ func (u UUID) String() string {
 	}
 	// Note: First three fields use LE byte order, last two use BE (network).
 	// Reasons for this are described in 7.2.1 (basically: historic).
-	// dmidecode(8) only does this for 2.6+ SMBIOS versions but we don't make that distinction.
+	// Versions before 2.6 did not say so, see legacyString.
 	return fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%02x%02x-%02x%02x%02x%02x%02x%02x",
 		u[3], u[2], u[1], u[0],
 		u[5], u[4],
@@ -88,6 +143,56 @@ func (u UUID) String() string {
 	)
 }
 
+// legacyOrder returns the UUID that String prints with all bytes in table
+// order, the order dmidecode(8) uses for SMBIOS versions before 2.6.
+func (u UUID) legacyOrder() UUID {
+	return UUID{
+		u[3], u[2], u[1], u[0],
+		u[5], u[4],
+		u[7], u[6],
+		u[8], u[9],
+		u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// Unlike String, the all-zeroes and all-ones UUIDs are also given in canonical form.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%02x%02x%02x%02x-%02x%02x-%02x%02x-%02x%02x-%02x%02x%02x%02x%02x%02x",
+		u[3], u[2], u[1], u[0],
+		u[5], u[4],
+		u[7], u[6],
+		u[8], u[9],
+		u[10], u[11], u[12], u[13], u[14], u[15],
+	)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(text []byte) error {
+	var b [16]byte
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return fmt.Errorf("invalid UUID %q", text)
+	}
+	h := make([]byte, 0, 32)
+	h = append(h, text[0:8]...)
+	h = append(h, text[9:13]...)
+	h = append(h, text[14:18]...)
+	h = append(h, text[19:23]...)
+	h = append(h, text[24:36]...)
+	if _, err := hex.Decode(b[:], h); err != nil {
+		return fmt.Errorf("invalid UUID %q: %w", text, err)
+	}
+	*u = UUID{
+		b[3], b[2], b[1], b[0],
+		b[5], b[4],
+		b[7], b[6],
+		b[8], b[9],
+		b[10], b[11], b[12], b[13], b[14], b[15],
+	}
+	return nil
+}
+
 // WakeupType is defined in DSP0134 7.2.2.
 type WakeupType uint8
 
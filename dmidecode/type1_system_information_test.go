@@ -97,6 +97,54 @@ System Information
 	SKU Number: 3a
 	Family: UR00T1234`,
 		},
+		{
+			name: "SMBIOS 2.4",
+			val: SystemInfo{
+				Header: smbios.Header{
+					Type:   smbios.TableTypeSystemInfo,
+					Length: 27,
+				},
+				tableVersion: tableVersion{smbios.Version{Major: 2, Minor: 4}},
+				Manufacturer: "u-root testing",
+				ProductName:  "Illusion",
+				Version:      "1.0",
+				SerialNumber: "UR00T1234",
+				UUID:         UUID{0x0, 0x1, 0x2, 0x3, 0x4, 0x5, 0x6, 0x7, 0x8, 0x9, 0xa, 0xb, 0xc, 0xd, 0xe, 0xf},
+				WakeupType:   WakeupTypePowerSwitch,
+				SKUNumber:    "3a",
+				Family:       "UR00T1234",
+			},
+			want: `Handle 0x0000, DMI type 1, 27 bytes
+System Information
+	Manufacturer: u-root testing
+	Product Name: Illusion
+	Version: 1.0
+	Serial Number: UR00T1234
+	UUID: 00010203-0405-0607-0809-0a0b0c0d0e0f
+	Wake-up Type: Power Switch
+	SKU Number: 3a
+	Family: UR00T1234`,
+		},
+		{
+			name: "SMBIOS 2.0",
+			val: SystemInfo{
+				Header: smbios.Header{
+					Type:   smbios.TableTypeSystemInfo,
+					Length: 8,
+				},
+				tableVersion: tableVersion{smbios.Version{Major: 2, Minor: 0}},
+				Manufacturer: "u-root testing",
+				ProductName:  "Illusion",
+				Version:      "1.0",
+				SerialNumber: "UR00T1234",
+			},
+			want: `Handle 0x0000, DMI type 1, 8 bytes
+System Information
+	Manufacturer: u-root testing
+	Product Name: Illusion
+	Version: 1.0
+	Serial Number: UR00T1234`,
+		},
 	}
 
 	for _, tt := range tests {
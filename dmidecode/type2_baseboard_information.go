@@ -14,7 +14,7 @@ import (
 
 // BaseboardInfo is defined in DSP0134 7.3.
 type BaseboardInfo struct {
-	smbios.Header     `smbios:"-"`
+	smbios.Header     `smbios:"-" json:"Header"`
 	Manufacturer      string        // 04h
 	Product           string        // 05h
 	Version           string        // 06h
@@ -44,6 +44,12 @@ func ParseBaseboardInfo(t *smbios.Table) (*BaseboardInfo, error) {
 	return bi, nil
 }
 
+// Has reports whether the table contains field, the name of a BaseboardInfo
+// field. Absent fields are zero.
+func (bi *BaseboardInfo) Has(field string) bool {
+	return hasField(bi, bi.Header, smbios.Version{}, field)
+}
+
 func (bi *BaseboardInfo) String() string {
 	lines := []string{
 		bi.Header.String(),
@@ -91,6 +97,16 @@ func (v BoardFeatures) String() string {
 	return "\t\t" + strings.Join(lines, "\n\t\t")
 }
 
+// MarshalJSON implements json.Marshaler.
+func (v BoardFeatures) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, boardFeatureStr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *BoardFeatures) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, boardFeatureStr)
+}
+
 // BoardType is defined in DSP0134 7.3.2.
 type BoardType uint8
 
@@ -145,6 +161,11 @@ func (oh ObjectHandles) String() string {
 	return strings.Join(lines, "\n")
 }
 
+func (oh ObjectHandles) fieldSize() int {
+	// Handle count, followed by the handles.
+	return 1 + 2*len(oh)
+}
+
 func (oh ObjectHandles) str() string {
 	lines := []string{fmt.Sprintf("Contained Object Handles: %d", len(oh))}
 	for _, h := range oh {
@@ -14,7 +14,7 @@ import (
 
 // IPMIDeviceInfo is defined in DSP0134 7.39.
 type IPMIDeviceInfo struct {
-	smbios.Header                    `smbios:"-"`
+	smbios.Header                    `smbios:"-" json:"Header"`
 	InterfaceType                    BMCInterfaceType // 04h
 	IPMISpecificationRevision        uint8            // 05h
 	I2CSlaveAddress                  uint8            // 06h
@@ -39,6 +39,12 @@ func ParseIPMIDeviceInfo(t *smbios.Table) (*IPMIDeviceInfo, error) {
 	return di, nil
 }
 
+// Has reports whether the table contains field, the name of a IPMIDeviceInfo
+// field. Absent fields are zero.
+func (di *IPMIDeviceInfo) Has(field string) bool {
+	return hasField(di, di.Header, smbios.Version{}, field)
+}
+
 func (di *IPMIDeviceInfo) String() string {
 	nvs := "Not Present"
 	if di.NVStorageDeviceAddress != 0xff {
@@ -16,21 +16,24 @@ import (
 
 // ChassisInfo is defined in DSP0134 7.4.
 type ChassisInfo struct {
-	smbios.Header      `smbios:"-"`
+	smbios.Header      `smbios:"-" json:"Header"`
+	tableVersion       `smbios:"-"`
 	Manufacturer       string                   // 04h
 	Type               ChassisType              // 05h
 	Version            string                   // 06h
 	SerialNumber       string                   // 07h
 	AssetTagNumber     string                   // 08h
-	BootupState        ChassisState             // 09h
+	BootupState        ChassisState             `smbios:"since=2.1"` // 09h
 	PowerSupplyState   ChassisState             // 0Ah
 	ThermalState       ChassisState             // 0Bh
 	SecurityStatus     ChassisSecurityStatus    // 0Ch
-	OEMInfo            uint32                   // 0Dh
+	OEMInfo            uint32                   `smbios:"since=2.3"` // 0Dh
 	Height             uint8                    // 11h
 	NumberOfPowerCords uint8                    // 12h
 	ContainedElements  ChassisContainedElements // 13h
-	SKUNumber          string                   // 15h + CEC * CERL
+	SKUNumber          string                   `smbios:"since=2.7"` // 15h + CEC * CERL
+	RackType           ChassisRackType          `smbios:"since=3.9"` // 16h + CEC * CERL
+	RackHeight         uint8                    // 17h + CEC * CERL
 }
 
 // ChassisContainedElement is defined in DSP0134 7.4.4.
@@ -41,7 +44,10 @@ type ChassisContainedElement struct {
 }
 
 func (cce ChassisContainedElement) String() string {
-	return fmt.Sprintf("%s %d-%d", cce.Type, cce.Min, cce.Max)
+	if cce.Min == cce.Max {
+		return fmt.Sprintf("%s (%d)", cce.Type.str(), cce.Min)
+	}
+	return fmt.Sprintf("%s (%d-%d)", cce.Type.str(), cce.Min, cce.Max)
 }
 
 // ParseChassisInfo parses a generic smbios.Table into ChassisInfo.
@@ -52,7 +58,7 @@ func ParseChassisInfo(t *smbios.Table) (*ChassisInfo, error) {
 	if t.Len() < 0x9 {
 		return nil, fmt.Errorf("%w: system info table must be at least %d bytes", io.ErrUnexpectedEOF, 9)
 	}
-	si := &ChassisInfo{Header: t.Header}
+	si := &ChassisInfo{Header: t.Header, tableVersion: tableVersion{t.Version}}
 	_, err := parseStruct(t, 0 /* off */, false /* complete */, si)
 	if err != nil {
 		return nil, err
@@ -60,6 +66,12 @@ func ParseChassisInfo(t *smbios.Table) (*ChassisInfo, error) {
 	return si, nil
 }
 
+// Has reports whether the table contains field, the name of a ChassisInfo
+// field. Absent fields are zero.
+func (si *ChassisInfo) Has(field string) bool {
+	return hasField(si, si.Header, si.version, field)
+}
+
 func (si *ChassisInfo) String() string {
 	lockStr := "Not Present"
 	if si.Type&0x80 != 0 {
@@ -74,7 +86,7 @@ func (si *ChassisInfo) String() string {
 		fmt.Sprintf("Serial Number: %s", smbiosStr(si.SerialNumber)),
 		fmt.Sprintf("Asset Tag: %s", si.AssetTagNumber),
 	}
-	if si.Header.Length >= 9 { // 2.1+
+	if si.Has("SecurityStatus") {
 		lines = append(lines,
 			fmt.Sprintf("Boot-up State: %s", si.BootupState),
 			fmt.Sprintf("Power Supply State: %s", si.PowerSupplyState),
@@ -82,7 +94,10 @@ func (si *ChassisInfo) String() string {
 			fmt.Sprintf("Security Status: %s", si.SecurityStatus),
 		)
 	}
-	if si.Header.Length >= 0xd { // 2.3+
+	if si.Has("OEMInfo") {
+		lines = append(lines, fmt.Sprintf("OEM Information: 0x%08X", si.OEMInfo))
+	}
+	if si.Has("NumberOfPowerCords") {
 		heightStr, numPCStr := "Unspecified", "Unspecified"
 		if si.Height != 0 {
 			heightStr = fmt.Sprintf("%d U", si.Height)
@@ -91,17 +106,28 @@ func (si *ChassisInfo) String() string {
 			numPCStr = fmt.Sprintf("%d", si.NumberOfPowerCords)
 		}
 		lines = append(lines,
-			fmt.Sprintf("OEM Information: 0x%08X", si.OEMInfo),
 			fmt.Sprintf("Height: %s", heightStr),
 			fmt.Sprintf("Number Of Power Cords: %s", numPCStr),
-			si.ContainedElements.str(),
 		)
 	}
-	if int(si.Header.Length) > 0x15+len(si.ContainedElements)*3 {
+	if si.Has("ContainedElements") {
+		lines = append(lines, si.ContainedElements.str())
+	}
+	if si.Has("SKUNumber") {
 		lines = append(lines,
 			fmt.Sprintf("SKU Number: %s", smbiosStr(si.SKUNumber)),
 		)
 	}
+	if si.Has("RackHeight") {
+		heightStr := "Unspecified"
+		if si.RackHeight != 0 {
+			heightStr = fmt.Sprintf("%d %s", si.RackHeight, si.RackType.unit())
+		}
+		lines = append(lines,
+			fmt.Sprintf("Rack Type: %s", si.RackType),
+			fmt.Sprintf("Rack Height: %s", heightStr),
+		)
+	}
 	return strings.Join(lines, "\n\t")
 }
 
@@ -251,14 +277,139 @@ func (v ChassisSecurityStatus) String() string {
 	return fmt.Sprintf("%#x", uint8(v))
 }
 
-// ChassisElementType is defined in DSP0134 7.4.4.
+// ChassisElementType is defined in DSP0134 7.4.4. Bit 7 selects whether the
+// element is a structure of the SMBIOS type given by bits 6:0, or a board of
+// the BoardType given by bits 6:0.
 type ChassisElementType uint8
 
+// NewBoardElementType returns the element type of boards of type bt.
+func NewBoardElementType(bt BoardType) ChassisElementType {
+	return ChassisElementType(bt & 0x7f)
+}
+
+// NewTableElementType returns the element type of SMBIOS structures of type tt.
+func NewTableElementType(tt smbios.TableType) ChassisElementType {
+	return ChassisElementType(tt&0x7f) | 0x80
+}
+
+// BoardType returns the type of the element if it is a board.
+func (v ChassisElementType) BoardType() (BoardType, bool) {
+	return BoardType(v & 0x7f), v&0x80 == 0
+}
+
+// TableType returns the type of the element if it is an SMBIOS structure.
+func (v ChassisElementType) TableType() (smbios.TableType, bool) {
+	return smbios.TableType(v & 0x7f), v&0x80 != 0
+}
+
 func (v ChassisElementType) String() string {
-	if v&0x80 != 0 {
-		return smbios.TableType(v & 0x7f).String()
+	if tt, ok := v.TableType(); ok {
+		return tt.String()
+	}
+	bt, _ := v.BoardType()
+	return bt.String()
+}
+
+// str returns the name of the element type as dmidecode prints it.
+func (v ChassisElementType) str() string {
+	if tt, ok := v.TableType(); ok {
+		if int(tt) < len(chassisElementTableTypeStr) {
+			return chassisElementTableTypeStr[tt]
+		}
+		return outOfSpec
+	}
+	bt, _ := v.BoardType()
+	if _, ok := boardStrings[bt]; ok {
+		return bt.String()
+	}
+	return outOfSpec
+}
+
+// chassisElementTableTypeStr are the short names of SMBIOS structure types that
+// dmidecode uses for contained elements.
+var chassisElementTableTypeStr = []string{
+	"BIOS",
+	"System",
+	"Base Board",
+	"Chassis",
+	"Processor",
+	"Memory Controller",
+	"Memory Module",
+	"Cache",
+	"Port Connector",
+	"System Slots",
+	"On Board Devices",
+	"OEM Strings",
+	"System Configuration Options",
+	"BIOS Language",
+	"Group Associations",
+	"System Event Log",
+	"Physical Memory Array",
+	"Memory Device",
+	"32-bit Memory Error",
+	"Memory Array Mapped Address",
+	"Memory Device Mapped Address",
+	"Built-in Pointing Device",
+	"Portable Battery",
+	"System Reset",
+	"Hardware Security",
+	"System Power Controls",
+	"Voltage Probe",
+	"Cooling Device",
+	"Temperature Probe",
+	"Electrical Current Probe",
+	"Out-of-band Remote Access",
+	"Boot Integrity Services",
+	"System Boot",
+	"64-bit Memory Error",
+	"Management Device",
+	"Management Device Component",
+	"Management Device Threshold Data",
+	"Memory Channel",
+	"IPMI Device",
+	"Power Supply",
+	"Additional Information",
+	"Onboard Device",
+	"Management Controller Host Interface",
+	"TPM Device",
+	"Processor Additional Information",
+	"Firmware Inventory Information",
+	"String Property",
+}
+
+// ChassisRackType is defined in DSP0134 7.4 since version 3.9. It gives the
+// unit of RackHeight.
+type ChassisRackType uint8
+
+// ChassisRackType values are defined in DSP0134 7.4.
+const (
+	ChassisRackTypeUnspecified ChassisRackType = 0x00 // Unspecified
+	ChassisRackTypeRU          ChassisRackType = 0x01 // RU, EIA-310 rack unit of 44.45 mm
+	ChassisRackTypeOU          ChassisRackType = 0x02 // OU, Open Rack unit of 48 mm
+)
+
+var chassisRackTypeStr = map[ChassisRackType]string{
+	ChassisRackTypeUnspecified: "Unspecified",
+	ChassisRackTypeRU:          "RU",
+	ChassisRackTypeOU:          "OU",
+}
+
+func (v ChassisRackType) String() string {
+	if name, ok := chassisRackTypeStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
+
+// unit returns the unit of rack heights of type v.
+func (v ChassisRackType) unit() string {
+	switch v {
+	case ChassisRackTypeRU:
+		return "U"
+	case ChassisRackTypeOU:
+		return "OU"
 	}
-	return BoardType(v & 0x7f).String()
+	return "units"
 }
 
 // ChassisContainedElements are defined by DSP0134 7.4.4.
@@ -272,6 +423,11 @@ func (cec ChassisContainedElements) String() string {
 	return strings.Join(lines, "\n")
 }
 
+func (cec ChassisContainedElements) fieldSize() int {
+	// Element count and record length, followed by the elements.
+	return 2 + 3*len(cec)
+}
+
 func (cec ChassisContainedElements) str() string {
 	lines := []string{fmt.Sprintf("Contained Elements: %d", len(cec))}
 	for _, e := range cec {
@@ -54,6 +54,51 @@ func TestChassisInfoString(t *testing.T) {
 				SKUNumber: "Four",
 			},
 			want: `Handle 0x0000, DMI type 3, 14 bytes
+Chassis Information
+	Manufacturer: The Ancients
+	Type: All In One
+	Lock: Not Present
+	Version: One
+	Serial Number: TheAncients-01
+	Asset Tag: Two
+	Boot-up State: Safe
+	Power Supply State: Safe
+	Thermal State: Non-recoverable
+	Security Status: Unknown`,
+		}, {
+			name: "Full Information, 27 bytes",
+			val: ChassisInfo{
+				Header: smbios.Header{
+					Type:   smbios.TableTypeChassisInfo,
+					Length: 0x1b,
+				},
+				Manufacturer:       "The Ancients",
+				Type:               ChassisTypeAllInOne,
+				Version:            "One",
+				SerialNumber:       "TheAncients-01",
+				AssetTagNumber:     "Two",
+				BootupState:        ChassisStateSafe,
+				PowerSupplyState:   ChassisStateSafe,
+				ThermalState:       ChassisStateNonrecoverable,
+				SecurityStatus:     ChassisSecurityStatusUnknown,
+				OEMInfo:            0xABCD0123,
+				Height:             3,
+				NumberOfPowerCords: 1,
+				ContainedElements: []ChassisContainedElement{
+					{
+						Type: ChassisElementType(8),
+						Min:  3,
+						Max:  11,
+					},
+					{
+						Type: ChassisElementType(0),
+						Min:  0,
+						Max:  1,
+					},
+				},
+				SKUNumber: "Four",
+			},
+			want: `Handle 0x0000, DMI type 3, 27 bytes
 Chassis Information
 	Manufacturer: The Ancients
 	Type: All In One
@@ -69,8 +114,56 @@ Chassis Information
 	Height: 3 U
 	Number Of Power Cords: 1
 	Contained Elements: 2
-		Memory Module 3-11
-		0x0 0-1`,
+		Memory Module (3-11)
+		<OUT OF SPEC> (0-1)`,
+		}, {
+			name: "Rack",
+			val: ChassisInfo{
+				Header: smbios.Header{
+					Type:   smbios.TableTypeChassisInfo,
+					Length: 0x1e,
+				},
+				Manufacturer:       "The Ancients",
+				Type:               ChassisTypeRackMountChassis,
+				Version:            "One",
+				SerialNumber:       "TheAncients-03",
+				AssetTagNumber:     "Two",
+				BootupState:        ChassisStateSafe,
+				PowerSupplyState:   ChassisStateSafe,
+				ThermalState:       ChassisStateSafe,
+				SecurityStatus:     ChassisSecurityStatusNone,
+				NumberOfPowerCords: 2,
+				ContainedElements: []ChassisContainedElement{
+					{
+						Type: NewTableElementType(smbios.TableTypeProcessorInfo),
+						Min:  2,
+						Max:  2,
+					},
+				},
+				SKUNumber:  "Four",
+				RackType:   ChassisRackTypeOU,
+				RackHeight: 2,
+			},
+			want: `Handle 0x0000, DMI type 3, 30 bytes
+Chassis Information
+	Manufacturer: The Ancients
+	Type: Rack Mount Chassis
+	Lock: Not Present
+	Version: One
+	Serial Number: TheAncients-03
+	Asset Tag: Two
+	Boot-up State: Safe
+	Power Supply State: Safe
+	Thermal State: Safe
+	Security Status: None
+	OEM Information: 0x00000000
+	Height: Unspecified
+	Number Of Power Cords: 2
+	Contained Elements: 1
+		Processor (2)
+	SKU Number: Four
+	Rack Type: OU
+	Rack Height: 2 OU`,
 		}, {
 			name: "Minimal Information",
 			val: ChassisInfo{
@@ -152,6 +245,37 @@ func TestChassisTypeString(t *testing.T) {
 	}
 }
 
+func TestChassisElementType(t *testing.T) {
+	for _, tt := range []struct {
+		val   ChassisElementType
+		board BoardType
+		table smbios.TableType
+		str   string
+	}{
+		{val: NewBoardElementType(BoardTypeServerBlade), board: BoardTypeServerBlade, str: "Server Blade"},
+		{val: 0x0a, board: BoardTypeMotherboardIncludesProcessorMemoryAndIO, str: "Motherboard"},
+		{val: NewTableElementType(smbios.TableTypeMemoryDevice), table: smbios.TableTypeMemoryDevice, str: "Memory Device"},
+		{val: 0x84, table: smbios.TableTypeProcessorInfo, str: "Processor"},
+		{val: 0x00, board: 0, str: "<OUT OF SPEC>"},
+		{val: 0xff, table: 0x7f, str: "<OUT OF SPEC>"},
+	} {
+		bt, isBoard := tt.val.BoardType()
+		tbl, isTable := tt.val.TableType()
+		if isBoard == isTable {
+			t.Errorf("%#x: BoardType() ok = %v, TableType() ok = %v, want exactly one", uint8(tt.val), isBoard, isTable)
+		}
+		if isBoard && bt != tt.board {
+			t.Errorf("%#x.BoardType() = %v, want %v", uint8(tt.val), bt, tt.board)
+		}
+		if isTable && tbl != tt.table {
+			t.Errorf("%#x.TableType() = %v, want %v", uint8(tt.val), tbl, tt.table)
+		}
+		if got := tt.val.str(); got != tt.str {
+			t.Errorf("%#x.str() = %q, want %q", uint8(tt.val), got, tt.str)
+		}
+	}
+}
+
 func TestParseChassisInfo(t *testing.T) {
 	for _, tt := range []struct {
 		name  string
@@ -267,6 +391,50 @@ func TestParseChassisInfo(t *testing.T) {
 				OEMInfo:          0x1234,
 			},
 		},
+		{
+			name: "Parse 3.9 rack fields",
+			table: &smbios.Table{
+				Header: smbios.Header{
+					Type: smbios.TableTypeChassisInfo,
+				},
+				Version: smbios.Version{Major: 3, Minor: 9},
+				Data: []byte{
+					0x00,
+					0x17, // type
+					0x00,
+					0x00,
+					0x00,
+					0x03, 0x03, 0x03, // states
+					0x03,                   // security
+					0x00, 0x00, 0x00, 0x00, // oem info
+					0x00, // height
+					0x01, // num power
+					0x01, // num elems
+					0x03, // elem size
+					0x84, 0x01, 0x02,
+					0x00, // SKU
+					0x01, // rack type
+					0x04, // rack height
+				},
+			},
+			want: &ChassisInfo{
+				Header: smbios.Header{
+					Type: smbios.TableTypeChassisInfo,
+				},
+				tableVersion:       tableVersion{smbios.Version{Major: 3, Minor: 9}},
+				Type:               ChassisTypeRackMountChassis,
+				BootupState:        0x03,
+				PowerSupplyState:   0x03,
+				ThermalState:       0x03,
+				SecurityStatus:     0x03,
+				NumberOfPowerCords: 1,
+				ContainedElements: []ChassisContainedElement{
+					{Type: NewTableElementType(smbios.TableTypeProcessorInfo), Min: 1, Max: 2},
+				},
+				RackType:   ChassisRackTypeRU,
+				RackHeight: 4,
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := ParseChassisInfo(tt.table)
@@ -5,16 +5,19 @@
 package dmidecode
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/registry"
 )
 
 // TPMDevice is defined in DSP0134 7.44.
 type TPMDevice struct {
-	smbios.Header    `smbios:"-"`
+	smbios.Header    `smbios:"-" json:"Header"`
 	VendorID         TPMDeviceVendorID        `smbios:"-,skip=4"` // 04h
 	MajorSpecVersion uint8                    // 08h
 	MinorSpecVersion uint8                    // 09h
@@ -42,6 +45,12 @@ func ParseTPMDevice(t *smbios.Table) (*TPMDevice, error) {
 	return di, nil
 }
 
+// Has reports whether the table contains field, the name of a TPMDevice field.
+// Absent fields are zero.
+func (di *TPMDevice) Has(field string) bool {
+	return hasField(di, di.Header, smbios.Version{}, field)
+}
+
 func (di *TPMDevice) String() string {
 	lines := []string{
 		di.Header.String(),
@@ -70,16 +79,41 @@ func (di *TPMDevice) String() string {
 type TPMDeviceVendorID [4]byte
 
 func (vid TPMDeviceVendorID) String() string {
-	// DSP0134 specifies Vendor ID field as 4 BYTEs, not a DWORD, and gives an example value.
-	// But Infineon ignores it and puts their VID in LE byte order so it ends up backwards.
-	if vid[0] == 0 && vid[1] == 'X' && vid[2] == 'F' && vid[3] == 'I' {
-		return "IFX"
+	return registry.TPMVendorID(vid).String()
+}
+
+// Name returns the name of the vendor in the TCG Vendor ID Registry, or an
+// empty string if the ID is not known.
+func (vid TPMDeviceVendorID) Name() string {
+	return registry.TPMVendorID(vid).Name()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+//
+// The ID is given as its raw characters without trailing NULs,
+// or as "0x"-prefixed hex if it contains non-printable characters.
+func (vid TPMDeviceVendorID) MarshalText() ([]byte, error) {
+	s := strings.TrimRight(string(vid[:]), "\x00")
+	for _, c := range []byte(s) {
+		if c != 0 && (c < 0x20 || c > 0x7e) {
+			return []byte(fmt.Sprintf("0x%02x%02x%02x%02x", vid[0], vid[1], vid[2], vid[3])), nil
+		}
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (vid *TPMDeviceVendorID) UnmarshalText(text []byte) error {
+	if len(text) == 10 && bytes.HasPrefix(text, []byte("0x")) {
+		_, err := hex.Decode(vid[:], text[2:])
+		return err
 	}
-	s := ""
-	for i := 0; i < 4 && vid[i] != 0; i++ {
-		s += string(vid[i])
+	if len(text) > len(vid) {
+		return fmt.Errorf("invalid TPM vendor ID %q", text)
 	}
-	return s
+	*vid = TPMDeviceVendorID{}
+	copy(vid[:], text)
+	return nil
 }
 
 // TPMDeviceCharacteristics is defined in DSP0134 7.44.1.
@@ -93,19 +127,32 @@ const (
 	TPMDeviceCharacteristicsFamilyConfigurableViaOEMProprietaryMechanism TPMDeviceCharacteristics = 1 << 5 // Family configurable via OEM proprietary mechanism.
 )
 
+var tpmDeviceCharacteristicsStr = map[TPMDeviceCharacteristics]string{
+	TPMDeviceCharacteristicsNotSupported:                                 "TPM Device characteristics not supported",
+	TPMDeviceCharacteristicsFamilyConfigurableViaFirmwareUpdate:          "Family configurable via firmware update",
+	TPMDeviceCharacteristicsFamilyConfigurableViaPlatformSoftwareSupport: "Family configurable via platform software support",
+	TPMDeviceCharacteristicsFamilyConfigurableViaOEMProprietaryMechanism: "Family configurable via OEM proprietary mechanism",
+}
+
 func (v TPMDeviceCharacteristics) String() string {
 	if v&TPMDeviceCharacteristicsNotSupported != 0 {
-		return "\t\tTPM Device characteristics not supported"
+		return "\t\t" + tpmDeviceCharacteristicsStr[TPMDeviceCharacteristicsNotSupported]
 	}
 	var lines []string
-	if v&TPMDeviceCharacteristicsFamilyConfigurableViaFirmwareUpdate != 0 {
-		lines = append(lines, "Family configurable via firmware update")
-	}
-	if v&TPMDeviceCharacteristicsFamilyConfigurableViaPlatformSoftwareSupport != 0 {
-		lines = append(lines, "Family configurable via platform software support")
-	}
-	if v&TPMDeviceCharacteristicsFamilyConfigurableViaOEMProprietaryMechanism != 0 {
-		lines = append(lines, "Family configurable via OEM proprietary mechanism")
+	for i := 3; i < 6; i++ {
+		if v&(1<<i) != 0 {
+			lines = append(lines, tpmDeviceCharacteristicsStr[1<<i])
+		}
 	}
 	return "\t\t" + strings.Join(lines, "\n\t\t")
 }
+
+// MarshalJSON implements json.Marshaler.
+func (v TPMDeviceCharacteristics) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, tpmDeviceCharacteristicsStr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *TPMDeviceCharacteristics) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, tpmDeviceCharacteristicsStr)
+}
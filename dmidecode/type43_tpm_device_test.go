@@ -191,3 +191,18 @@ func TestNewTPMDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestTPMDeviceVendorIDName(t *testing.T) {
+	for _, tt := range []struct {
+		vid  TPMDeviceVendorID
+		want string
+	}{
+		{vid: TPMDeviceVendorID{0x0, 'X', 'F', 'I'}, want: "Infineon"},
+		{vid: TPMDeviceVendorID{'N', 'T', 'C', 0}, want: "Nuvoton Technology"},
+		{vid: TPMDeviceVendorID{'X', 'Y', 'Z', 0}, want: ""},
+	} {
+		if got := tt.vid.Name(); got != tt.want {
+			t.Errorf("%s.Name() = %q, want %q", tt.vid, got, tt.want)
+		}
+	}
+}
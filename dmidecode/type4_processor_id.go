@@ -0,0 +1,290 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ProcessorID is the raw processor ID of DSP0134 7.5.3, whose format depends
+// on the processor family, see IDFormat.
+type ProcessorID uint64
+
+// MarshalText implements encoding.TextMarshaler. IDs are given as hex, as
+// many exceed the integers that JSON numbers represent exactly.
+func (v ProcessorID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("0x%016x", uint64(v))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorID) UnmarshalText(text []byte) error {
+	n, err := strconv.ParseUint(string(text), 0, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %T %q", *v, text)
+	}
+	*v = ProcessorID(n)
+	return nil
+}
+
+// ProcessorIDFormat tells how the ID field of ProcessorInfo is to be read. It
+// is implied by the processor family, see DSP0134 7.5.3.
+type ProcessorIDFormat int
+
+// ProcessorIDFormat values.
+const (
+	ProcessorIDFormatUnknown   ProcessorIDFormat = iota
+	ProcessorIDFormatIntel                       // CPUID leaf 1 EAX and EDX, Intel encoding.
+	ProcessorIDFormatAMD                         // CPUID leaf 1 EAX and EDX, AMD encoding.
+	ProcessorIDFormatARM                         // Main ID Register (MIDR).
+	ProcessorIDFormatRISCV                       // Machine Vendor ID CSR (mvendorid) of hart 0.
+	ProcessorIDFormatLoongArch                   // Processor ID (PRID) from CPUCFG word 0.
+)
+
+// IDFormat returns the format of the processor ID, according to the processor
+// family.
+func (pi *ProcessorInfo) IDFormat() ProcessorIDFormat {
+	switch f := pi.GetFamily(); {
+	case (f >= 0x0B && f <= 0x15) || /* Intel, Cyrix */
+		(f >= 0x28 && f <= 0x2F) ||
+		(f >= 0xA1 && f <= 0xB3) ||
+		f == 0xB5 ||
+		(f >= 0xB9 && f <= 0xC7) ||
+		(f >= 0xCD && f <= 0xCF) ||
+		(f >= 0xD2 && f <= 0xDB) || /* VIA, Intel */
+		(f >= 0xDD && f <= 0xE0):
+		return ProcessorIDFormatIntel
+	case (f >= 0x18 && f <= 0x1D) ||
+		f == 0x1F ||
+		(f >= 0x38 && f <= 0x3F) ||
+		(f >= 0x46 && f <= 0x4F) ||
+		(f >= 0x66 && f <= 0x6B) ||
+		(f >= 0x83 && f <= 0x8F) ||
+		(f >= 0xB6 && f <= 0xB7) ||
+		(f >= 0xE4 && f <= 0xEF):
+		return ProcessorIDFormatAMD
+	case (f >= 0x100 && f <= 0x101) ||
+		(f >= 0x118 && f <= 0x119):
+		return ProcessorIDFormatARM
+	case f >= 0x200 && f <= 0x202:
+		return ProcessorIDFormatRISCV
+	case (f >= 0x258 && f <= 0x262) ||
+		(f >= 0x26C && f <= 0x271):
+		return ProcessorIDFormatLoongArch
+	}
+	return ProcessorIDFormatUnknown
+}
+
+// X86Signature is the processor signature of x86 processors, decoded from
+// CPUID leaf 1 EAX. Family and Model include the extended family and model.
+type X86Signature struct {
+	Type     uint8 // Only defined by Intel.
+	Family   uint16
+	Model    uint8
+	Stepping uint8
+}
+
+// X86Signature returns the processor signature of Intel and AMD processors.
+func (pi *ProcessorInfo) X86Signature() (X86Signature, bool) {
+	eax := uint32(pi.ID & 0xffffffff)
+	switch pi.IDFormat() {
+	case ProcessorIDFormatIntel:
+		return X86Signature{
+			Type:     uint8((eax >> 12) & 0x3),
+			Family:   uint16(((eax >> 20) & 0xff) + ((eax >> 8) & 0xf)),
+			Model:    uint8(((eax >> 12) & 0xf0) + ((eax >> 4) & 0xf)),
+			Stepping: uint8(eax & 0xf),
+		}, true
+	case ProcessorIDFormatAMD:
+		fam := (eax >> 8) & 0xf
+		mod := (eax >> 4) & 0xf
+		if fam == 0xf {
+			fam += (eax >> 20) & 0xff
+			mod += (eax >> 12) & 0xf0
+		}
+		return X86Signature{
+			Family:   uint16(fam),
+			Model:    uint8(mod),
+			Stepping: uint8(eax & 0xf),
+		}, true
+	}
+	return X86Signature{}, false
+}
+
+// Flags returns the CPUID leaf 1 EDX feature flags of Intel and AMD processors.
+func (pi *ProcessorInfo) Flags() (ProcessorFlags, bool) {
+	switch pi.IDFormat() {
+	case ProcessorIDFormatIntel, ProcessorIDFormatAMD:
+		return ProcessorFlags(pi.ID >> 32), true
+	}
+	return 0, false
+}
+
+// ARMMIDR is the Main ID Register of ARM processors.
+type ARMMIDR struct {
+	Implementer  uint8
+	Variant      uint8
+	Architecture uint8
+	PartNumber   uint16
+	Revision     uint8
+}
+
+// ARMMIDR returns the Main ID Register of ARM processors. The format of the ID
+// was not defined for ARM before SMBIOS 3.1.0, so an ID of all zeroes is
+// ignored.
+func (pi *ProcessorInfo) ARMMIDR() (ARMMIDR, bool) {
+	midr := uint32(pi.ID & 0xffffffff)
+	if pi.IDFormat() != ProcessorIDFormatARM || midr == 0 {
+		return ARMMIDR{}, false
+	}
+	return ARMMIDR{
+		Implementer:  uint8(midr >> 24),
+		Variant:      uint8((midr >> 20) & 0xf),
+		Architecture: uint8((midr >> 16) & 0xf),
+		PartNumber:   uint16((midr >> 4) & 0xfff),
+		Revision:     uint8(midr & 0xf),
+	}, true
+}
+
+// RISCVVendorID is the Machine Vendor ID CSR (mvendorid) of a RISC-V hart,
+// which holds a JEDEC manufacturer ID.
+type RISCVVendorID uint64
+
+// Continuations returns the number of JEDEC continuation codes, that is the
+// JEDEC bank number minus one.
+func (v RISCVVendorID) Continuations() int {
+	return int(v >> 7)
+}
+
+// Offset returns the JEDEC manufacturer ID within its bank, without parity.
+func (v RISCVVendorID) Offset() uint8 {
+	return uint8(v & 0x7f)
+}
+
+// RISCVVendorID returns the vendor ID of hart 0 of RISC-V processors.
+func (pi *ProcessorInfo) RISCVVendorID() (RISCVVendorID, bool) {
+	if pi.IDFormat() != ProcessorIDFormatRISCV {
+		return 0, false
+	}
+	return RISCVVendorID(pi.ID), true
+}
+
+// LoongArchPRID is the processor ID of LoongArch processors, read from CPUCFG
+// word 0.
+type LoongArchPRID uint32
+
+// Company returns the company ID, 0x14 for Loongson.
+func (v LoongArchPRID) Company() uint8 {
+	return uint8(v >> 16)
+}
+
+// Series returns the processor series, such as 0xc for LA464 cores.
+func (v LoongArchPRID) Series() uint8 {
+	return uint8((v >> 12) & 0xf)
+}
+
+// Revision returns the processor revision.
+func (v LoongArchPRID) Revision() uint8 {
+	return uint8(v)
+}
+
+// LoongArchPRID returns the processor ID of LoongArch processors.
+func (pi *ProcessorInfo) LoongArchPRID() (LoongArchPRID, bool) {
+	if pi.IDFormat() != ProcessorIDFormatLoongArch {
+		return 0, false
+	}
+	return LoongArchPRID(pi.ID & 0xffffffff), true
+}
+
+// ProcessorFlags are the x86 CPUID leaf 1 EDX feature flags, DSP0134 7.5.3.
+type ProcessorFlags uint32
+
+// ProcessorFlags fields are defined in DSP0134 7.5.3.
+const (
+	ProcessorFlagFPU   ProcessorFlags = 1 << 0  // Floating-point unit on-chip
+	ProcessorFlagVME   ProcessorFlags = 1 << 1  // Virtual mode extension
+	ProcessorFlagDE    ProcessorFlags = 1 << 2  // Debugging extension
+	ProcessorFlagPSE   ProcessorFlags = 1 << 3  // Page size extension
+	ProcessorFlagTSC   ProcessorFlags = 1 << 4  // Time stamp counter
+	ProcessorFlagMSR   ProcessorFlags = 1 << 5  // Model specific registers
+	ProcessorFlagPAE   ProcessorFlags = 1 << 6  // Physical address extension
+	ProcessorFlagMCE   ProcessorFlags = 1 << 7  // Machine check exception
+	ProcessorFlagCX8   ProcessorFlags = 1 << 8  // CMPXCHG8 instruction supported
+	ProcessorFlagAPIC  ProcessorFlags = 1 << 9  // On-chip APIC hardware supported
+	ProcessorFlagSEP   ProcessorFlags = 1 << 11 // Fast system call
+	ProcessorFlagMTRR  ProcessorFlags = 1 << 12 // Memory type range registers
+	ProcessorFlagPGE   ProcessorFlags = 1 << 13 // Page global enable
+	ProcessorFlagMCA   ProcessorFlags = 1 << 14 // Machine check architecture
+	ProcessorFlagCMOV  ProcessorFlags = 1 << 15 // Conditional move instruction supported
+	ProcessorFlagPAT   ProcessorFlags = 1 << 16 // Page attribute table
+	ProcessorFlagPSE36 ProcessorFlags = 1 << 17 // 36-bit page size extension
+	ProcessorFlagPSN   ProcessorFlags = 1 << 18 // Processor serial number present and enabled
+	ProcessorFlagCLFSH ProcessorFlags = 1 << 19 // CLFLUSH instruction supported
+	ProcessorFlagDS    ProcessorFlags = 1 << 21 // Debug store
+	ProcessorFlagACPI  ProcessorFlags = 1 << 22 // ACPI supported
+	ProcessorFlagMMX   ProcessorFlags = 1 << 23 // MMX technology supported
+	ProcessorFlagFXSR  ProcessorFlags = 1 << 24 // FXSAVE and FXSTOR instructions supported
+	ProcessorFlagSSE   ProcessorFlags = 1 << 25 // Streaming SIMD extensions
+	ProcessorFlagSSE2  ProcessorFlags = 1 << 26 // Streaming SIMD extensions 2
+	ProcessorFlagSS    ProcessorFlags = 1 << 27 // Self-snoop
+	ProcessorFlagHTT   ProcessorFlags = 1 << 28 // Multi-threading
+	ProcessorFlagTM    ProcessorFlags = 1 << 29 // Thermal monitor supported
+	ProcessorFlagPBE   ProcessorFlags = 1 << 31 // Pending break enabled
+)
+
+var processorFlagsStr = map[ProcessorFlags]string{
+	ProcessorFlagFPU:   "FPU (Floating-point unit on-chip)",
+	ProcessorFlagVME:   "VME (Virtual mode extension)",
+	ProcessorFlagDE:    "DE (Debugging extension)",
+	ProcessorFlagPSE:   "PSE (Page size extension)",
+	ProcessorFlagTSC:   "TSC (Time stamp counter)",
+	ProcessorFlagMSR:   "MSR (Model specific registers)",
+	ProcessorFlagPAE:   "PAE (Physical address extension)",
+	ProcessorFlagMCE:   "MCE (Machine check exception)",
+	ProcessorFlagCX8:   "CX8 (CMPXCHG8 instruction supported)",
+	ProcessorFlagAPIC:  "APIC (On-chip APIC hardware supported)",
+	ProcessorFlagSEP:   "SEP (Fast system call)",
+	ProcessorFlagMTRR:  "MTRR (Memory type range registers)",
+	ProcessorFlagPGE:   "PGE (Page global enable)",
+	ProcessorFlagMCA:   "MCA (Machine check architecture)",
+	ProcessorFlagCMOV:  "CMOV (Conditional move instruction supported)",
+	ProcessorFlagPAT:   "PAT (Page attribute table)",
+	ProcessorFlagPSE36: "PSE-36 (36-bit page size extension)",
+	ProcessorFlagPSN:   "PSN (Processor serial number present and enabled)",
+	ProcessorFlagCLFSH: "CLFSH (CLFLUSH instruction supported)",
+	ProcessorFlagDS:    "DS (Debug store)",
+	ProcessorFlagACPI:  "ACPI (ACPI supported)",
+	ProcessorFlagMMX:   "MMX (MMX technology supported)",
+	ProcessorFlagFXSR:  "FXSR (FXSAVE and FXSTOR instructions supported)",
+	ProcessorFlagSSE:   "SSE (Streaming SIMD extensions)",
+	ProcessorFlagSSE2:  "SSE2 (Streaming SIMD extensions 2)",
+	ProcessorFlagSS:    "SS (Self-snoop)",
+	ProcessorFlagHTT:   "HTT (Multi-threading)",
+	ProcessorFlagTM:    "TM (Thermal monitor supported)",
+	ProcessorFlagPBE:   "PBE (Pending break enabled)",
+}
+
+// String returns the names of the flags that are set, one per line. Reserved
+// bits are ignored.
+func (v ProcessorFlags) String() string {
+	var lines []string
+	for i := 0; i < 32; i++ {
+		if name := processorFlagsStr[1<<i]; v&(1<<i) != 0 && name != "" {
+			lines = append(lines, name)
+		}
+	}
+	return "\t\t" + strings.Join(lines, "\n\t\t")
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v ProcessorFlags) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, processorFlagsStr)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *ProcessorFlags) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, processorFlagsStr)
+}
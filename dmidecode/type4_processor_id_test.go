@@ -0,0 +1,138 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dmidecode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/u-root/smbios"
+)
+
+func processorWithID(family ProcessorFamily, id uint64) *ProcessorInfo {
+	pi := &ProcessorInfo{
+		Header: smbios.Header{Type: smbios.TableTypeProcessorInfo, Length: 0x30},
+		Family: ProcessorFamilyByte(family),
+		ID:     ProcessorID(id),
+	}
+	if family > 0xfd {
+		pi.Family, pi.Family2 = 0xfe, family
+	}
+	return pi
+}
+
+func TestProcessorID(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		pi         *ProcessorInfo
+		wantFormat ProcessorIDFormat
+		wantSig    X86Signature
+		wantFlags  ProcessorFlags
+		wantMIDR   ARMMIDR
+	}{
+		{
+			name:       "Intel",
+			pi:         processorWithID(ProcessorFamilyXeon, 0xbfebfbff000306e4),
+			wantFormat: ProcessorIDFormatIntel,
+			wantSig:    X86Signature{Family: 6, Model: 62, Stepping: 4},
+			wantFlags:  0xbfebfbff,
+		},
+		{
+			name:       "AMD",
+			pi:         processorWithID(ProcessorFamilyZen, 0x178bfbff00800f11),
+			wantFormat: ProcessorIDFormatAMD,
+			wantSig:    X86Signature{Family: 23, Model: 1, Stepping: 1},
+			wantFlags:  0x178bfbff,
+		},
+		{
+			name:       "ARM",
+			pi:         processorWithID(ProcessorFamilyARMv8, 0x413fd0c1),
+			wantFormat: ProcessorIDFormatARM,
+			wantMIDR:   ARMMIDR{Implementer: 0x41, Variant: 3, Architecture: 0xf, PartNumber: 0xd0c, Revision: 1},
+		},
+		{
+			name:       "ARM without MIDR",
+			pi:         processorWithID(ProcessorFamilyARMv8, 0),
+			wantFormat: ProcessorIDFormatARM,
+		},
+		{
+			name:       "Unknown",
+			pi:         processorWithID(ProcessorFamilyOther, 0x178bfbff00800f11),
+			wantFormat: ProcessorIDFormatUnknown,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pi.IDFormat(); got != tt.wantFormat {
+				t.Errorf("IDFormat() = %v, want %v", got, tt.wantFormat)
+			}
+			isX86 := tt.wantFormat == ProcessorIDFormatIntel || tt.wantFormat == ProcessorIDFormatAMD
+			if got, ok := tt.pi.X86Signature(); got != tt.wantSig || ok != isX86 {
+				t.Errorf("X86Signature() = %+v, %t, want %+v, %t", got, ok, tt.wantSig, isX86)
+			}
+			if got, ok := tt.pi.Flags(); got != tt.wantFlags || ok != isX86 {
+				t.Errorf("Flags() = %#x, %t, want %#x, %t", got, ok, tt.wantFlags, isX86)
+			}
+			hasMIDR := tt.wantMIDR != ARMMIDR{}
+			if got, ok := tt.pi.ARMMIDR(); got != tt.wantMIDR || ok != hasMIDR {
+				t.Errorf("ARMMIDR() = %+v, %t, want %+v, %t", got, ok, tt.wantMIDR, hasMIDR)
+			}
+		})
+	}
+}
+
+func TestProcessorIDIntelType(t *testing.T) {
+	// Pentium OverDrive, type 1.
+	pi := processorWithID(ProcessorFamilyPentium, 0x1531)
+	got, ok := pi.X86Signature()
+	if want := (X86Signature{Type: 1, Family: 5, Model: 3, Stepping: 1}); !ok || got != want {
+		t.Errorf("X86Signature() = %+v, %t, want %+v, true", got, ok, want)
+	}
+}
+
+func TestRISCVVendorID(t *testing.T) {
+	// SiFive, JEDEC bank 10, ID 0x89.
+	pi := processorWithID(ProcessorFamilyRISCVRV64, 0x489)
+	v, ok := pi.RISCVVendorID()
+	if !ok || v.Continuations() != 9 || v.Offset() != 0x09 {
+		t.Errorf("RISCVVendorID() = %#x, %t: %d continuations, offset %#x", uint64(v), ok, v.Continuations(), v.Offset())
+	}
+	if _, ok := processorWithID(ProcessorFamilyARMv8, 0x489).RISCVVendorID(); ok {
+		t.Errorf("RISCVVendorID() of an ARM processor = _, true, want false")
+	}
+}
+
+func TestLoongArchPRID(t *testing.T) {
+	pi := processorWithID(ProcessorFamilyMultiCoreLoongson3A5xxx, 0x0014c010)
+	v, ok := pi.LoongArchPRID()
+	if !ok || v.Company() != 0x14 || v.Series() != 0xc || v.Revision() != 0x10 {
+		t.Errorf("LoongArchPRID() = %#x, %t: company %#x, series %#x, revision %#x", uint32(v), ok, v.Company(), v.Series(), v.Revision())
+	}
+	if _, ok := processorWithID(ProcessorFamilyRISCVRV64, 0).LoongArchPRID(); ok {
+		t.Errorf("LoongArchPRID() of a RISC-V processor = _, true, want false")
+	}
+}
+
+func TestProcessorFlagsString(t *testing.T) {
+	for _, tt := range []struct {
+		flags ProcessorFlags
+		want  string
+	}{
+		{ProcessorFlagFPU | ProcessorFlagPBE, "\t\tFPU (Floating-point unit on-chip)\n\t\tPBE (Pending break enabled)"},
+		// Reserved bits have no name.
+		{1<<10 | ProcessorFlagSSE2, "\t\tSSE2 (Streaming SIMD extensions 2)"},
+		{0, "\t\t"},
+	} {
+		if got := tt.flags.String(); got != tt.want {
+			t.Errorf("ProcessorFlags(%#x).String() = %q, want %q", uint32(tt.flags), got, tt.want)
+		}
+	}
+}
+
+func TestProcessorInfoStringNoFlags(t *testing.T) {
+	pi := processorWithID(ProcessorFamilyXeon, 0x000306e4)
+	if got := pi.String(); !strings.Contains(got, "\n\tFlags: None\n") {
+		t.Errorf("String() = %q, want Flags: None", got)
+	}
+}
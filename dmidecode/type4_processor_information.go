@@ -10,18 +10,20 @@ import (
 	"strings"
 
 	"github.com/u-root/smbios"
+	"github.com/u-root/smbios/registry"
 )
 
 // Much of this is auto-generated. If adding a new type, see README for instructions.
 
 // ProcessorInfo is defined in DSP0134 x.x.
 type ProcessorInfo struct {
-	smbios.Header     `smbios:"-"`
+	smbios.Header     `smbios:"-" json:"Header"`
+	tableVersion      `smbios:"-"`
 	SocketDesignation string                   // 04h
 	Type              ProcessorType            // 05h
-	Family            uint8                    // 06h
+	Family            ProcessorFamilyByte      // 06h
 	Manufacturer      string                   // 07h
-	ID                uint64                   // 08h
+	ID                ProcessorID              // 08h
 	Version           string                   // 10h
 	Voltage           uint8                    // 11h
 	ExternalClock     uint16                   // 12h
@@ -32,17 +34,19 @@ type ProcessorInfo struct {
 	L1CacheHandle     uint16                   // 1Ah
 	L2CacheHandle     uint16                   // 1Ch
 	L3CacheHandle     uint16                   // 1Eh
-	SerialNumber      string                   // 20h
+	SerialNumber      string                   `smbios:"since=2.3"` // 20h
 	AssetTag          string                   // 21h
 	PartNumber        string                   // 22h
-	CoreCount         uint8                    // 23h
+	CoreCount         uint8                    `smbios:"since=2.5"` // 23h
 	CoreEnabled       uint8                    // 24h
 	ThreadCount       uint8                    // 25h
 	Characteristics   ProcessorCharacteristics // 26h
-	Family2           ProcessorFamily          // 28h
-	CoreCount2        uint16                   // 2Ah
+	Family2           ProcessorFamily          `smbios:"since=2.6"` // 28h
+	CoreCount2        uint16                   `smbios:"since=3.0"` // 2Ah
 	CoreEnabled2      uint16                   // 2Ch
 	ThreadCount2      uint16                   // 2Eh
+	ThreadEnabled     uint16                   `smbios:"since=3.6"` // 30h
+	SocketType        string                   // 32h
 }
 
 // ParseProcessorInfo parses a generic smbios.Table into ProcessorInfo.
@@ -53,7 +57,7 @@ func ParseProcessorInfo(t *smbios.Table) (*ProcessorInfo, error) {
 	if t.Len() < 0x1a {
 		return nil, fmt.Errorf("%w: processor info table must be at least %d bytes", io.ErrUnexpectedEOF, 0x1a)
 	}
-	pi := &ProcessorInfo{Header: t.Header}
+	pi := &ProcessorInfo{Header: t.Header, tableVersion: tableVersion{t.Version}}
 	_, err := parseStruct(t, 0 /* off */, false /* complete */, pi)
 	if err != nil {
 		return nil, err
@@ -61,14 +65,27 @@ func ParseProcessorInfo(t *smbios.Table) (*ProcessorInfo, error) {
 	return pi, nil
 }
 
+// Has reports whether the table contains field, the name of a ProcessorInfo
+// field. Absent fields are zero or have their default value.
+func (pi *ProcessorInfo) Has(field string) bool {
+	return hasField(pi, pi.Header, pi.version, field)
+}
+
 // GetFamily returns the processor family, taken from the appropriate field.
 func (pi *ProcessorInfo) GetFamily() ProcessorFamily {
-	if pi.Family == 0xfe && pi.Header.Length >= 0x2a {
+	if pi.Family == 0xfe && pi.Has("Family2") {
 		return pi.Family2
 	}
 	return ProcessorFamily(pi.Family)
 }
 
+// GetManufacturer returns the common name of the processor manufacturer, as
+// normalized by registry.NormalizeVendor, or an empty string if it is not
+// specified.
+func (pi *ProcessorInfo) GetManufacturer() string {
+	return registry.NormalizeVendor(pi.Manufacturer)
+}
+
 // GetVoltage returns the processor voltage, in volts.
 func (pi *ProcessorInfo) GetVoltage() float32 {
 	if pi.Voltage&0x80 == 0 {
@@ -87,7 +104,7 @@ func (pi *ProcessorInfo) GetVoltage() float32 {
 
 // GetCoreCount returns the number of cores detected by the BIOS for this processor socket.
 func (pi *ProcessorInfo) GetCoreCount() int {
-	if pi.Length >= 0x2c && pi.CoreCount == 0xff {
+	if pi.CoreCount == 0xff && pi.Has("CoreCount2") {
 		return int(pi.CoreCount2)
 	}
 	return int(pi.CoreCount)
@@ -95,7 +112,7 @@ func (pi *ProcessorInfo) GetCoreCount() int {
 
 // GetCoreEnabled returns the number of cores that are enabled by the BIOS and available for Operating System use.
 func (pi *ProcessorInfo) GetCoreEnabled() int {
-	if pi.Length >= 0x2e && pi.CoreEnabled == 0xff {
+	if pi.CoreEnabled == 0xff && pi.Has("CoreEnabled2") {
 		return int(pi.CoreEnabled2)
 	}
 	return int(pi.CoreEnabled)
@@ -103,12 +120,18 @@ func (pi *ProcessorInfo) GetCoreEnabled() int {
 
 // GetThreadCount returns the total number of threads detected by the BIOS for this processor socket.
 func (pi *ProcessorInfo) GetThreadCount() int {
-	if pi.Length >= 0x30 && pi.ThreadCount == 0xff {
+	if pi.ThreadCount == 0xff && pi.Has("ThreadCount2") {
 		return int(pi.ThreadCount2)
 	}
 	return int(pi.ThreadCount)
 }
 
+// GetThreadEnabled returns the number of threads that are enabled by the BIOS
+// and available for Operating System use. It is 0 if unknown.
+func (pi *ProcessorInfo) GetThreadEnabled() int {
+	return int(pi.ThreadEnabled)
+}
+
 func (pi *ProcessorInfo) String() string {
 	freqStr := func(v uint16) string {
 		if v == 0 {
@@ -122,50 +145,15 @@ func (pi *ProcessorInfo) String() string {
 		}
 		return fmt.Sprintf("0x%04X", h)
 	}
-	f := pi.GetFamily()
 	sig := ""
-	haveFlags := false
-	switch {
-	// Intel
-	case (f >= 0x0B && f <= 0x15) || /* Intel, Cyrix */
-		(f >= 0x28 && f <= 0x2F) ||
-		(f >= 0xA1 && f <= 0xB3) ||
-		f == 0xB5 ||
-		(f >= 0xB9 && f <= 0xC7) ||
-		(f >= 0xCD && f <= 0xCF) ||
-		(f >= 0xD2 && f <= 0xDB) || /* VIA, Intel */
-		(f >= 0xDD && f <= 0xE0):
-		eax := uint32(pi.ID & 0xffffffff)
-		sig = fmt.Sprintf("Type %d, Family %d, Model %d, Stepping %d",
-			(eax>>12)&0x3, ((eax>>20)&0xff)+((eax>>8)&0xf), ((eax>>12)&0xf0)+((eax>>4)&0xf), eax&0xf)
-		haveFlags = true
-	// AMD
-	case (f >= 0x18 && f <= 0x1D) ||
-		f == 0x1F ||
-		(f >= 0x38 && f <= 0x3F) ||
-		(f >= 0x46 && f <= 0x4F) ||
-		(f >= 0x66 && f <= 0x6B) ||
-		(f >= 0x83 && f <= 0x8F) ||
-		(f >= 0xB6 && f <= 0xB7) ||
-		(f >= 0xE4 && f <= 0xEF):
-		eax := uint32(pi.ID & 0xffffffff)
-		fam := (eax >> 8) & 0xf
-		if ((eax >> 8) & 0xf) == 0xf {
-			fam += (eax >> 20) & 0xff
-		}
-		mod := (eax >> 4) & 0xf
-		if ((eax >> 8) & 0xf) == 0xf {
-			mod += (eax >> 12) & 0xf0
-		}
-		sig = fmt.Sprintf("Family %d, Model %d, Stepping %d", fam, mod, eax&0xf)
-		haveFlags = true
-	// ARM
-	case (f >= 0x100 && f <= 0x101) ||
-		(f >= 0x118 && f <= 0x119):
-		if midr := uint32(pi.ID & 0xffffffff); midr != 0 {
-			sig = fmt.Sprintf("Implementor 0x%02x, Variant 0x%x, Architecture %d, Part 0x%03x, Revision %d",
-				midr>>24, (midr>>20)&0xf, (midr>>16)&0xf, (midr>>4)&0xfff, midr&0xf)
+	if xs, ok := pi.X86Signature(); ok {
+		sig = fmt.Sprintf("Family %d, Model %d, Stepping %d", xs.Family, xs.Model, xs.Stepping)
+		if pi.IDFormat() == ProcessorIDFormatIntel {
+			sig = fmt.Sprintf("Type %d, %s", xs.Type, sig)
 		}
+	} else if midr, ok := pi.ARMMIDR(); ok {
+		sig = fmt.Sprintf("Implementor 0x%02x, Variant 0x%x, Architecture %d, Part 0x%03x, Revision %d",
+			midr.Implementer, midr.Variant, midr.Architecture, midr.PartNumber, midr.Revision)
 	}
 	lines := []string{
 		pi.Header.String(),
@@ -181,46 +169,11 @@ func (pi *ProcessorInfo) String() string {
 	if sig != "" {
 		lines = append(lines, fmt.Sprintf("Signature: %s", sig))
 	}
-	if haveFlags {
-		lines = append(lines, "Flags:")
-		edx := uint32(pi.ID >> 32)
-		for n, s := range [32]string{
-			"FPU (Floating-point unit on-chip)", /* 0 */
-			"VME (Virtual mode extension)",
-			"DE (Debugging extension)",
-			"PSE (Page size extension)",
-			"TSC (Time stamp counter)",
-			"MSR (Model specific registers)",
-			"PAE (Physical address extension)",
-			"MCE (Machine check exception)",
-			"CX8 (CMPXCHG8 instruction supported)",
-			"APIC (On-chip APIC hardware supported)",
-			"", /* 10 */
-			"SEP (Fast system call)",
-			"MTRR (Memory type range registers)",
-			"PGE (Page global enable)",
-			"MCA (Machine check architecture)",
-			"CMOV (Conditional move instruction supported)",
-			"PAT (Page attribute table)",
-			"PSE-36 (36-bit page size extension)",
-			"PSN (Processor serial number present and enabled)",
-			"CLFSH (CLFLUSH instruction supported)",
-			"", /* 20 */
-			"DS (Debug store)",
-			"ACPI (ACPI supported)",
-			"MMX (MMX technology supported)",
-			"FXSR (FXSAVE and FXSTOR instructions supported)",
-			"SSE (Streaming SIMD extensions)",
-			"SSE2 (Streaming SIMD extensions 2)",
-			"SS (Self-snoop)",
-			"HTT (Multi-threading)",
-			"TM (Thermal monitor supported)",
-			"",                            /* 30 */
-			"PBE (Pending break enabled)", /* 31 */
-		} {
-			if edx&(1<<uint(n)) != 0 && s != "" {
-				lines = append(lines, "\t"+s)
-			}
+	if flags, ok := pi.Flags(); ok {
+		if fs := flags.String(); strings.TrimSpace(fs) != "" {
+			lines = append(lines, "Flags:\n"+fs)
+		} else {
+			lines = append(lines, "Flags: None")
 		}
 	}
 	lines = append(lines,
@@ -232,21 +185,24 @@ func (pi *ProcessorInfo) String() string {
 		fmt.Sprintf("Status: %s", pi.Status),
 		fmt.Sprintf("Upgrade: %s", pi.Upgrade),
 	)
-	if pi.Length > 0x1a {
+	if pi.Has("SocketType") {
+		lines = append(lines, fmt.Sprintf("Socket Type: %s", smbiosStr(pi.SocketType)))
+	}
+	if pi.Has("L3CacheHandle") {
 		lines = append(lines,
 			fmt.Sprintf("L1 Cache Handle: %s", cacheHandleStr(pi.L1CacheHandle)),
 			fmt.Sprintf("L2 Cache Handle: %s", cacheHandleStr(pi.L2CacheHandle)),
 			fmt.Sprintf("L3 Cache Handle: %s", cacheHandleStr(pi.L3CacheHandle)),
 		)
 	}
-	if pi.Length > 0x20 {
+	if pi.Has("PartNumber") {
 		lines = append(lines,
 			fmt.Sprintf("Serial Number: %s", smbiosStr(pi.SerialNumber)),
 			fmt.Sprintf("Asset Tag: %s", smbiosStr(pi.AssetTag)),
 			fmt.Sprintf("Part Number: %s", smbiosStr(pi.PartNumber)),
 		)
 	}
-	if pi.Length > 0x23 {
+	if pi.Has("Characteristics") {
 		lines = append(lines,
 			fmt.Sprintf("Core Count: %d", pi.GetCoreCount()),
 			fmt.Sprintf("Core Enabled: %d", pi.GetCoreEnabled()),
@@ -254,6 +210,9 @@ func (pi *ProcessorInfo) String() string {
 		if pi.GetThreadCount() > 0 {
 			lines = append(lines, fmt.Sprintf("Thread Count: %d", pi.GetThreadCount()))
 		}
+		if pi.GetThreadEnabled() > 0 {
+			lines = append(lines, fmt.Sprintf("Thread Enabled: %d", pi.GetThreadEnabled()))
+		}
 		lines = append(lines, fmt.Sprintf("Characteristics:\n%s", pi.Characteristics))
 	}
 	return strings.Join(lines, "\n\t")
@@ -504,6 +463,26 @@ const (
 	ProcessorFamilyWinChip                      ProcessorFamily = 0x140 // WinChip
 	ProcessorFamilyDSP                          ProcessorFamily = 0x15e // DSP
 	ProcessorFamilyVideoProcessor               ProcessorFamily = 0x1f4 // Video Processor
+	ProcessorFamilyRISCVRV32                    ProcessorFamily = 0x200 // RISC-V RV32
+	ProcessorFamilyRISCVRV64                    ProcessorFamily = 0x201 // RISC-V RV64
+	ProcessorFamilyRISCVRV128                   ProcessorFamily = 0x202 // RISC-V RV128
+	ProcessorFamilyLoongArch                    ProcessorFamily = 0x258 // LoongArch
+	ProcessorFamilyLoongson1                    ProcessorFamily = 0x259 // Loongson 1
+	ProcessorFamilyLoongson2                    ProcessorFamily = 0x25a // Loongson 2
+	ProcessorFamilyLoongson3                    ProcessorFamily = 0x25b // Loongson 3
+	ProcessorFamilyLoongson2K                   ProcessorFamily = 0x25c // Loongson 2K
+	ProcessorFamilyLoongson3A                   ProcessorFamily = 0x25d // Loongson 3A
+	ProcessorFamilyLoongson3B                   ProcessorFamily = 0x25e // Loongson 3B
+	ProcessorFamilyLoongson3C                   ProcessorFamily = 0x25f // Loongson 3C
+	ProcessorFamilyLoongson3D                   ProcessorFamily = 0x260 // Loongson 3D
+	ProcessorFamilyLoongson3E                   ProcessorFamily = 0x261 // Loongson 3E
+	ProcessorFamilyDualCoreLoongson2K2xxx       ProcessorFamily = 0x262 // Dual-Core Loongson 2K 2xxx
+	ProcessorFamilyQuadCoreLoongson3A5xxx       ProcessorFamily = 0x26c // Quad-Core Loongson 3A 5xxx
+	ProcessorFamilyMultiCoreLoongson3A5xxx      ProcessorFamily = 0x26d // Multi-Core Loongson 3A 5xxx
+	ProcessorFamilyQuadCoreLoongson3B5xxx       ProcessorFamily = 0x26e // Quad-Core Loongson 3B 5xxx
+	ProcessorFamilyMultiCoreLoongson3B5xxx      ProcessorFamily = 0x26f // Multi-Core Loongson 3B 5xxx
+	ProcessorFamilyMultiCoreLoongson3C5xxx      ProcessorFamily = 0x270 // Multi-Core Loongson 3C 5xxx
+	ProcessorFamilyMultiCoreLoongson3D5xxx      ProcessorFamily = 0x271 // Multi-Core Loongson 3D 5xxx
 )
 
 var processorFamilyStr = map[ProcessorFamily]string{
@@ -719,6 +698,26 @@ var processorFamilyStr = map[ProcessorFamily]string{
 	ProcessorFamilyWinChip:                      "WinChip",
 	ProcessorFamilyDSP:                          "DSP",
 	ProcessorFamilyVideoProcessor:               "Video Processor",
+	ProcessorFamilyRISCVRV32:                    "RISC-V RV32",
+	ProcessorFamilyRISCVRV64:                    "RISC-V RV64",
+	ProcessorFamilyRISCVRV128:                   "RISC-V RV128",
+	ProcessorFamilyLoongArch:                    "LoongArch",
+	ProcessorFamilyLoongson1:                    "Loongson 1",
+	ProcessorFamilyLoongson2:                    "Loongson 2",
+	ProcessorFamilyLoongson3:                    "Loongson 3",
+	ProcessorFamilyLoongson2K:                   "Loongson 2K",
+	ProcessorFamilyLoongson3A:                   "Loongson 3A",
+	ProcessorFamilyLoongson3B:                   "Loongson 3B",
+	ProcessorFamilyLoongson3C:                   "Loongson 3C",
+	ProcessorFamilyLoongson3D:                   "Loongson 3D",
+	ProcessorFamilyLoongson3E:                   "Loongson 3E",
+	ProcessorFamilyDualCoreLoongson2K2xxx:       "Dual-Core Loongson 2K 2xxx",
+	ProcessorFamilyQuadCoreLoongson3A5xxx:       "Quad-Core Loongson 3A 5xxx",
+	ProcessorFamilyMultiCoreLoongson3A5xxx:      "Multi-Core Loongson 3A 5xxx",
+	ProcessorFamilyQuadCoreLoongson3B5xxx:       "Quad-Core Loongson 3B 5xxx",
+	ProcessorFamilyMultiCoreLoongson3B5xxx:      "Multi-Core Loongson 3B 5xxx",
+	ProcessorFamilyMultiCoreLoongson3C5xxx:      "Multi-Core Loongson 3C 5xxx",
+	ProcessorFamilyMultiCoreLoongson3D5xxx:      "Multi-Core Loongson 3D 5xxx",
 }
 
 func (v ProcessorFamily) String() string {
@@ -728,6 +727,32 @@ func (v ProcessorFamily) String() string {
 	return fmt.Sprintf("%#x", uint8(v))
 }
 
+// ProcessorFamilyByte is the one-byte processor family field of DSP0134 7.5.
+// It holds the ProcessorFamily values below 0xfe, 0xfe refers to Family2.
+type ProcessorFamilyByte uint8
+
+func (v ProcessorFamilyByte) String() string {
+	return ProcessorFamily(v).String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v ProcessorFamilyByte) MarshalText() ([]byte, error) {
+	return ProcessorFamily(v).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *ProcessorFamilyByte) UnmarshalText(text []byte) error {
+	var f ProcessorFamily
+	if err := f.UnmarshalText(text); err != nil {
+		return err
+	}
+	if f > 0xff {
+		return fmt.Errorf("invalid %T %q", *v, text)
+	}
+	*v = ProcessorFamilyByte(f)
+	return nil
+}
+
 // ProcessorStatus is defined in DSP0134 7.5.
 type ProcessorStatus uint8
 
@@ -899,6 +924,8 @@ const (
 	ProcessorCharacteristicsExecuteProtection       ProcessorCharacteristics = 1 << 5 // Execute Protection
 	ProcessorCharacteristicsEnhancedVirtualization  ProcessorCharacteristics = 1 << 6 // Enhanced Virtualization
 	ProcessorCharacteristicsPowerPerformanceControl ProcessorCharacteristics = 1 << 7 // Power/Performance Control
+	ProcessorCharacteristics128bitCapable           ProcessorCharacteristics = 1 << 8 // 128-bit Capable
+	ProcessorCharacteristicsArm64SoCID              ProcessorCharacteristics = 1 << 9 // Arm64 SoC ID
 )
 
 var procChars = map[ProcessorCharacteristics]string{
@@ -910,14 +937,26 @@ var procChars = map[ProcessorCharacteristics]string{
 	ProcessorCharacteristicsExecuteProtection:       "Execute Protection",
 	ProcessorCharacteristicsEnhancedVirtualization:  "Enhanced Virtualization",
 	ProcessorCharacteristicsPowerPerformanceControl: "Power/Performance Control",
+	ProcessorCharacteristics128bitCapable:           "128-bit Capable",
+	ProcessorCharacteristicsArm64SoCID:              "Arm64 SoC ID",
 }
 
 func (v ProcessorCharacteristics) String() string {
 	var lines []string
-	for i := 0; i < 8; i++ {
+	for i := 0; i < 10; i++ {
 		if v&(1<<i) != 0 {
 			lines = append(lines, procChars[1<<i])
 		}
 	}
 	return "\t\t" + strings.Join(lines, "\n\t\t")
 }
+
+// MarshalJSON implements json.Marshaler.
+func (v ProcessorCharacteristics) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, procChars)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *ProcessorCharacteristics) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, procChars)
+}
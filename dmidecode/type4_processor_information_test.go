@@ -8,6 +8,7 @@ import (
 	"errors"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/u-root/smbios"
@@ -46,6 +47,18 @@ func TestGetFamily(t *testing.T) {
 			},
 			want: 0xaa,
 		},
+		{
+			name: "Type 0xfe before 2.6",
+			val: ProcessorInfo{
+				Header: smbios.Header{
+					Length: 0x3a,
+				},
+				tableVersion: tableVersion{smbios.Version{Major: 2, Minor: 5}},
+				Family:       0xfe,
+				Family2:      0xaa,
+			},
+			want: 0xfe,
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,6 +167,26 @@ func TestGetCoreCount(t *testing.T) {
 	}
 }
 
+func TestGetManufacturer(t *testing.T) {
+	for _, tt := range []struct {
+		val  ProcessorInfo
+		want string
+	}{
+		{val: ProcessorInfo{Manufacturer: "GenuineIntel"}, want: "Intel"},
+		{val: ProcessorInfo{Manufacturer: "Intel(R) Corporation"}, want: "Intel"},
+		{val: ProcessorInfo{Manufacturer: "Advanced Micro Devices, Inc."}, want: "AMD"},
+		{val: ProcessorInfo{Manufacturer: "Not Specified"}, want: ""},
+		{val: ProcessorInfo{Manufacturer: "Ampere(R)"}, want: "Ampere(R)"},
+	} {
+		t.Run("", func(t *testing.T) {
+			got := tt.val.GetManufacturer()
+			if got != tt.want {
+				t.Errorf("GetManufacturer(%q) = %q, want %q", tt.val.Manufacturer, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetCoreEnabled(t *testing.T) {
 	for _, tt := range []struct {
 		val  ProcessorInfo
@@ -383,6 +416,16 @@ func TestProcessorCharacteristicsString(t *testing.T) {
 			val:  ProcessorCharacteristics(0x80),
 			want: "		Power/Performance Control",
 		},
+		{
+			name: "128-bit Capable Characteristics",
+			val:  ProcessorCharacteristics(0x100),
+			want: "		128-bit Capable",
+		},
+		{
+			name: "Arm64 SoC ID Characteristics",
+			val:  ProcessorCharacteristics(0x200),
+			want: "		Arm64 SoC ID",
+		},
 	}
 
 	for _, tt := range tests {
@@ -535,6 +578,7 @@ Processor Information
 	Current Speed: Unknown
 	Status: Unpopulated
 	Upgrade: 0x0
+	Socket Type: Not Specified
 	L1 Cache Handle: 0x1337
 	L2 Cache Handle: 0xDEAD
 	L3 Cache Handle: 0xBEEF
@@ -555,3 +599,80 @@ Processor Information
 		})
 	}
 }
+
+func TestParseProcessorInfo36(t *testing.T) {
+	fixture := func(length uint8, version smbios.Version) *smbios.Table {
+		data := make([]byte, 0x2f)
+		data[0x01] = uint8(ProcessorTypeCentralProcessor)
+		data[0x21] = 8                      // Thread Count.
+		data[0x2c], data[0x2d] = 0x06, 0x00 // Thread Enabled.
+		data[0x2e] = 1                      // Socket Type.
+		return &smbios.Table{
+			Header:  smbios.Header{Type: smbios.TableTypeProcessorInfo, Length: length},
+			Data:    data[:length-4],
+			Strings: []string{"LGA4677"},
+			Version: version,
+		}
+	}
+	v36 := smbios.Version{Major: 3, Minor: 6}
+	for _, tt := range []struct {
+		name              string
+		table             *smbios.Table
+		wantThreadEnabled int
+		wantSocketType    string
+		wantLines         []string
+		wantNoLines       []string
+	}{
+		{
+			name:        "SMBIOS 3.0",
+			table:       fixture(0x30, v36),
+			wantNoLines: []string{"Thread Enabled:", "Socket Type:"},
+		},
+		{
+			name:              "Thread Enabled",
+			table:             fixture(0x32, v36),
+			wantThreadEnabled: 6,
+			wantLines:         []string{"\n\tThread Count: 8\n\tThread Enabled: 6\n"},
+			wantNoLines:       []string{"Socket Type:"},
+		},
+		{
+			name:              "Socket Type",
+			table:             fixture(0x33, v36),
+			wantThreadEnabled: 6,
+			wantSocketType:    "LGA4677",
+			wantLines: []string{
+				"\n\tUpgrade: 0x0\n\tSocket Type: LGA4677\n",
+				"\n\tThread Enabled: 6\n",
+			},
+		},
+		{
+			name:        "SMBIOS 3.5",
+			table:       fixture(0x33, smbios.Version{Major: 3, Minor: 5}),
+			wantNoLines: []string{"Thread Enabled:", "Socket Type:"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pi, err := ParseProcessorInfo(tt.table)
+			if err != nil {
+				t.Fatalf("ParseProcessorInfo() = %v", err)
+			}
+			if got := pi.GetThreadEnabled(); got != tt.wantThreadEnabled {
+				t.Errorf("GetThreadEnabled() = %d, want %d", got, tt.wantThreadEnabled)
+			}
+			if pi.SocketType != tt.wantSocketType {
+				t.Errorf("SocketType = %q, want %q", pi.SocketType, tt.wantSocketType)
+			}
+			s := pi.String()
+			for _, l := range tt.wantLines {
+				if !strings.Contains(s, l) {
+					t.Errorf("String() = %s, want it to contain %q", s, l)
+				}
+			}
+			for _, l := range tt.wantNoLines {
+				if strings.Contains(s, l) {
+					t.Errorf("String() = %s, want it not to contain %q", s, l)
+				}
+			}
+		})
+	}
+}
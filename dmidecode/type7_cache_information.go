@@ -14,18 +14,19 @@ import (
 
 // CacheInfo is defined in DSP0134 7.8.
 type CacheInfo struct {
-	smbios.Header       `smbios:"-"`
+	smbios.Header       `smbios:"-" json:"Header"`
+	tableVersion        `smbios:"-"`
 	SocketDesignation   string                   // 04h
 	Configuration       uint16                   // 05h
 	MaximumSize         uint16                   // 07h
 	InstalledSize       uint16                   // 09h
 	SupportedSRAMType   CacheSRAMType            // 0Bh
 	CurrentSRAMType     CacheSRAMType            // 0Dh
-	Speed               uint8                    // 0Fh
+	Speed               uint8                    `smbios:"since=2.1"` // 0Fh
 	ErrorCorrectionType CacheErrorCorrectionType // 10h
 	SystemType          CacheSystemType          // 11h
 	Associativity       CacheAssociativity       // 12h
-	MaximumSize2        uint32                   // 13h
+	MaximumSize2        uint32                   `smbios:"since=3.1"` // 13h
 	InstalledSize2      uint32                   // 17h
 }
 
@@ -37,7 +38,7 @@ func ParseCacheInfo(t *smbios.Table) (*CacheInfo, error) {
 	if t.Len() < 0xf {
 		return nil, fmt.Errorf("%w: cache info table must be at least %d bytes", io.ErrUnexpectedEOF, 0xf)
 	}
-	ci := &CacheInfo{Header: t.Header}
+	ci := &CacheInfo{Header: t.Header, tableVersion: tableVersion{t.Version}}
 	_, err := parseStruct(t, 0 /* off */, false /* complete */, ci)
 	if err != nil {
 		return nil, err
@@ -45,65 +46,114 @@ func ParseCacheInfo(t *smbios.Table) (*CacheInfo, error) {
 	return ci, nil
 }
 
-func cacheSizeBytes2Or1(size1 uint16, size2 uint32) uint64 {
-	mul2 := uint64(1024)
-	if size2&0x80000000 != 0 {
-		mul2 *= 64
+// Has reports whether the table contains field, the name of a CacheInfo
+// field. Absent fields are zero.
+func (ci *CacheInfo) Has(field string) bool {
+	return hasField(ci, ci.Header, ci.version, field)
+}
+
+// cacheSizeBytes decodes a 16-bit cache size, whose bit 15 selects a
+// granularity of 64K rather than 1K.
+func cacheSizeBytes(size uint16) uint64 {
+	mul := uint64(1024)
+	if size&0x8000 != 0 {
+		mul *= 64
 	}
-	if size2Bytes := uint64(size2&0x7fffffff) * mul2; size2Bytes != 0 {
+	return uint64(size&0x7fff) * mul
+}
+
+// cacheSize2Bytes decodes a 32-bit cache size, whose bit 31 selects a
+// granularity of 64K rather than 1K.
+func cacheSize2Bytes(size uint32) uint64 {
+	mul := uint64(1024)
+	if size&0x80000000 != 0 {
+		mul *= 64
+	}
+	return uint64(size&0x7fffffff) * mul
+}
+
+// cacheSizeBytes2Or1 returns the cache size given by size2 if it is set,
+// otherwise the one given by size1.
+func cacheSizeBytes2Or1(size1 uint16, size2 uint32) uint64 {
+	if size2Bytes := cacheSize2Bytes(size2); size2Bytes != 0 {
 		return size2Bytes
 	}
-	mul1 := uint64(1024)
-	if size1&0x8000 != 0 {
-		mul1 *= 64
+	return cacheSizeBytes(size1)
+}
+
+// sizeBytes returns the size given by the 16-bit field size1 or by its 32-bit
+// counterpart size2, named field2. Caches of 2 GiB and larger set size1 to
+// 0xFFFF and only size2 holds their size.
+func (ci *CacheInfo) sizeBytes(size1 uint16, size2 uint32, field2 string) uint64 {
+	if !ci.Has(field2) {
+		return cacheSizeBytes(size1)
 	}
-	return uint64(size1&0x7fff) * mul1
+	if size1 == 0xffff {
+		return cacheSize2Bytes(size2)
+	}
+	return cacheSizeBytes2Or1(size1, size2)
+}
+
+// Level returns the cache level, 1 to 8.
+func (ci *CacheInfo) Level() int {
+	return int(ci.Configuration&7) + 1
 }
 
-// GetMaxSizeBytes returns the maximum size  of the cache that can be installed, in bytes.
+// Socketed reports whether the cache is socketed.
+func (ci *CacheInfo) Socketed() bool {
+	return ci.Configuration&0x8 != 0
+}
+
+// Location returns the location of the cache relative to the CPU module.
+func (ci *CacheInfo) Location() CacheLocation {
+	return CacheLocation(ci.Configuration >> 5 & 3)
+}
+
+// Enabled reports whether the cache is enabled at boot time.
+func (ci *CacheInfo) Enabled() bool {
+	return ci.Configuration&0x80 != 0
+}
+
+// OperationalMode returns the operational mode of the cache.
+func (ci *CacheInfo) OperationalMode() CacheOperationalMode {
+	return CacheOperationalMode(ci.Configuration >> 8 & 3)
+}
+
+// MaxSizeBytes returns the maximum size of the cache that can be installed, in
+// bytes.
+func (ci *CacheInfo) MaxSizeBytes() uint64 {
+	return ci.sizeBytes(ci.MaximumSize, ci.MaximumSize2, "MaximumSize2")
+}
+
+// InstalledSizeBytes returns the currently installed cache size, in bytes.
+func (ci *CacheInfo) InstalledSizeBytes() uint64 {
+	return ci.sizeBytes(ci.InstalledSize, ci.InstalledSize2, "InstalledSize2")
+}
+
+// GetMaxSizeBytes returns the maximum size of the cache that can be installed, in bytes.
+//
+// Deprecated: Use MaxSizeBytes.
 func (ci *CacheInfo) GetMaxSizeBytes() uint64 {
-	return cacheSizeBytes2Or1(ci.MaximumSize, ci.MaximumSize2)
+	return ci.MaxSizeBytes()
 }
 
 // GetInstalledSizeBytes returns the currently installed cache size, in bytes.
+//
+// Deprecated: Use InstalledSizeBytes.
 func (ci *CacheInfo) GetInstalledSizeBytes() uint64 {
-	return cacheSizeBytes2Or1(ci.InstalledSize, ci.InstalledSize2)
+	return ci.InstalledSizeBytes()
 }
 
 func (ci *CacheInfo) String() string {
 	enDis := "Disabled"
-	if ci.Configuration&0x80 != 0 {
+	if ci.Enabled() {
 		enDis = "Enabled"
 	}
 	sock := "Not Socketed"
-	if ci.Configuration&0x8 != 0 {
+	if ci.Socketed() {
 		sock = "Socketed"
 	}
 
-	om := ""
-	switch (ci.Configuration >> 8) & 3 {
-	case 0:
-		om = "Write Through"
-	case 1:
-		om = "Write Back"
-	case 2:
-		om = "Varies With Memory Address"
-	case 3:
-		om = "Unknown"
-	}
-
-	loc := ""
-	switch (ci.Configuration >> 5) & 3 {
-	case 0:
-		loc = "Internal"
-	case 1:
-		loc = "External"
-	case 2:
-		loc = "Reserved"
-	case 3:
-		loc = "Unknown"
-	}
-
 	speedStr := "Unknown"
 	if ci.Speed > 0 {
 		speedStr = fmt.Sprintf("%d ns", ci.Speed)
@@ -112,15 +162,15 @@ func (ci *CacheInfo) String() string {
 	lines := []string{
 		ci.Header.String(),
 		fmt.Sprintf("Socket Designation: %s", smbiosStr(ci.SocketDesignation)),
-		fmt.Sprintf("Configuration: %s, %s, Level %d", enDis, sock, (ci.Configuration&7)+1),
-		fmt.Sprintf("Operational Mode: %s", om),
-		fmt.Sprintf("Location: %s", loc),
-		fmt.Sprintf("Installed Size: %s", kmgt(ci.GetInstalledSizeBytes())),
-		fmt.Sprintf("Maximum Size: %s", kmgt(ci.GetMaxSizeBytes())),
+		fmt.Sprintf("Configuration: %s, %s, Level %d", enDis, sock, ci.Level()),
+		fmt.Sprintf("Operational Mode: %s", ci.OperationalMode()),
+		fmt.Sprintf("Location: %s", ci.Location()),
+		fmt.Sprintf("Installed Size: %s", kmgt(ci.InstalledSizeBytes())),
+		fmt.Sprintf("Maximum Size: %s", kmgt(ci.MaxSizeBytes())),
 		fmt.Sprintf("Supported SRAM Types:\n%s", ci.SupportedSRAMType),
 		fmt.Sprintf("Installed SRAM Type: %s", strings.TrimSpace(ci.CurrentSRAMType.String())),
 	}
-	if ci.Length > 0xf {
+	if ci.Has("Associativity") {
 		lines = append(lines,
 			fmt.Sprintf("Speed: %s", speedStr),
 			fmt.Sprintf("Error Correction Type: %s", ci.ErrorCorrectionType),
@@ -131,6 +181,58 @@ func (ci *CacheInfo) String() string {
 	return strings.Join(lines, "\n\t")
 }
 
+// CacheOperationalMode is defined in DSP0134 7.8, bits 9:8 of the cache
+// configuration.
+type CacheOperationalMode uint8
+
+// CacheOperationalMode values are defined in DSP0134 7.8.
+const (
+	CacheOperationalModeWriteThrough            CacheOperationalMode = 0x00 // Write Through
+	CacheOperationalModeWriteBack               CacheOperationalMode = 0x01 // Write Back
+	CacheOperationalModeVariesWithMemoryAddress CacheOperationalMode = 0x02 // Varies With Memory Address
+	CacheOperationalModeUnknown                 CacheOperationalMode = 0x03 // Unknown
+)
+
+var cacheOMStr = map[CacheOperationalMode]string{
+	CacheOperationalModeWriteThrough:            "Write Through",
+	CacheOperationalModeWriteBack:               "Write Back",
+	CacheOperationalModeVariesWithMemoryAddress: "Varies With Memory Address",
+	CacheOperationalModeUnknown:                 "Unknown",
+}
+
+func (v CacheOperationalMode) String() string {
+	if name, ok := cacheOMStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
+
+// CacheLocation is defined in DSP0134 7.8, bits 6:5 of the cache
+// configuration. It is relative to the CPU module.
+type CacheLocation uint8
+
+// CacheLocation values are defined in DSP0134 7.8.
+const (
+	CacheLocationInternal CacheLocation = 0x00 // Internal
+	CacheLocationExternal CacheLocation = 0x01 // External
+	CacheLocationReserved CacheLocation = 0x02 // Reserved
+	CacheLocationUnknown  CacheLocation = 0x03 // Unknown
+)
+
+var cacheLocStr = map[CacheLocation]string{
+	CacheLocationInternal: "Internal",
+	CacheLocationExternal: "External",
+	CacheLocationReserved: "Reserved",
+	CacheLocationUnknown:  "Unknown",
+}
+
+func (v CacheLocation) String() string {
+	if name, ok := cacheLocStr[v]; ok {
+		return name
+	}
+	return fmt.Sprintf("%#x", uint8(v))
+}
+
 // CacheSRAMType is defined in DSP0134 7.8.2.
 type CacheSRAMType uint16
 
@@ -165,6 +267,16 @@ func (v CacheSRAMType) String() string {
 	return "\t\t" + strings.Join(lines, "\n\t\t")
 }
 
+// MarshalJSON implements json.Marshaler.
+func (v CacheSRAMType) MarshalJSON() ([]byte, error) {
+	return marshalFlags(v, cacheSRAM)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *CacheSRAMType) UnmarshalJSON(data []byte) error {
+	return unmarshalFlags(data, v, cacheSRAM)
+}
+
 // CacheErrorCorrectionType is defined in DSP0134 7.8.3.
 type CacheErrorCorrectionType uint8
 
@@ -50,12 +50,121 @@ func TestCacheSizeBytes2Or1(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			size := cacheSizeBytes2Or1(tt.size1, tt.size2)
 			if size != tt.want {
-				t.Errorf("%q failed. Got: %q, Want: %q", tt.name, size, tt.want)
+				t.Errorf("%q failed. Got: %d, Want: %d", tt.name, size, tt.want)
 			}
 		})
 	}
 }
 
+func TestCacheInfoSizeBytes(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		ci        CacheInfo
+		max       uint64
+		installed uint64
+	}{
+		{
+			name: "Before 3.1",
+			ci: CacheInfo{
+				Header:        smbios.Header{Length: 0x13},
+				MaximumSize:   0x8010,
+				InstalledSize: 0x0100,
+			},
+			max:       1 << 20,
+			installed: 256 << 10,
+		},
+		{
+			name: "Before 3.1 ignores extended sizes",
+			ci: CacheInfo{
+				Header:         smbios.Header{Length: 0x13},
+				MaximumSize:    0x0100,
+				InstalledSize:  0x0100,
+				MaximumSize2:   0x0200,
+				InstalledSize2: 0x0200,
+			},
+			max:       256 << 10,
+			installed: 256 << 10,
+		},
+		{
+			name: "3.1 small cache",
+			ci: CacheInfo{
+				Header:         smbios.Header{Length: 0x1b},
+				MaximumSize:    0x8010,
+				InstalledSize:  0x8010,
+				MaximumSize2:   0x80000010,
+				InstalledSize2: 0x80000010,
+			},
+			max:       1 << 20,
+			installed: 1 << 20,
+		},
+		{
+			name: "3.1 2 GiB cache",
+			ci: CacheInfo{
+				Header:         smbios.Header{Length: 0x1b},
+				MaximumSize:    0xffff,
+				InstalledSize:  0xffff,
+				MaximumSize2:   0x80010000,
+				InstalledSize2: 0x80008000,
+			},
+			max:       4 << 30,
+			installed: 2 << 30,
+		},
+		{
+			name: "3.1 unset extended size",
+			ci: CacheInfo{
+				Header:        smbios.Header{Length: 0x1b},
+				MaximumSize:   0xffff,
+				InstalledSize: 0x0020,
+			},
+			max:       0,
+			installed: 32 << 10,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ci.MaxSizeBytes(); got != tt.max {
+				t.Errorf("MaxSizeBytes() = %#x, want %#x", got, tt.max)
+			}
+			if got := tt.ci.InstalledSizeBytes(); got != tt.installed {
+				t.Errorf("InstalledSizeBytes() = %#x, want %#x", got, tt.installed)
+			}
+		})
+	}
+}
+
+func TestCacheInfoConfiguration(t *testing.T) {
+	for _, tt := range []struct {
+		config   uint16
+		level    int
+		socketed bool
+		location CacheLocation
+		enabled  bool
+		mode     CacheOperationalMode
+	}{
+		{config: 0x0000, level: 1, location: CacheLocationInternal, mode: CacheOperationalModeWriteThrough},
+		{config: 0x0180, level: 1, enabled: true, location: CacheLocationInternal, mode: CacheOperationalModeWriteBack},
+		{config: 0x0281, level: 2, enabled: true, location: CacheLocationInternal, mode: CacheOperationalModeVariesWithMemoryAddress},
+		{config: 0x03aa, level: 3, socketed: true, enabled: true, location: CacheLocationExternal, mode: CacheOperationalModeUnknown},
+		{config: 0x0067, level: 8, location: CacheLocationUnknown, mode: CacheOperationalModeWriteThrough},
+	} {
+		ci := CacheInfo{Configuration: tt.config}
+		if got := ci.Level(); got != tt.level {
+			t.Errorf("%#04x: Level() = %d, want %d", tt.config, got, tt.level)
+		}
+		if got := ci.Socketed(); got != tt.socketed {
+			t.Errorf("%#04x: Socketed() = %v, want %v", tt.config, got, tt.socketed)
+		}
+		if got := ci.Location(); got != tt.location {
+			t.Errorf("%#04x: Location() = %v, want %v", tt.config, got, tt.location)
+		}
+		if got := ci.Enabled(); got != tt.enabled {
+			t.Errorf("%#04x: Enabled() = %v, want %v", tt.config, got, tt.enabled)
+		}
+		if got := ci.OperationalMode(); got != tt.mode {
+			t.Errorf("%#04x: OperationalMode() = %v, want %v", tt.config, got, tt.mode)
+		}
+	}
+}
+
 func TestCacheInfoString(t *testing.T) {
 	tests := []struct {
 		name string
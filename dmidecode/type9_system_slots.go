@@ -45,3 +45,9 @@ func ParseSystemSlots(t *smbios.Table) (*SystemSlots, error) {
 	}
 	return ss, nil
 }
+
+// Has reports whether the table contains field, the name of a SystemSlots
+// field. Absent fields are zero.
+func (ss *SystemSlots) Has(field string) bool {
+	return hasField(ss, ss.Header, ss.Table.Version, field)
+}
@@ -0,0 +1,148 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// GUID is a GUID in the mixed-endian binary layout used by EFI.
+type GUID [16]byte
+
+// String returns the GUID in canonical 8-4-4-4-12 form.
+func (g GUID) String() string {
+	return fmt.Sprintf("%08X-%04X-%04X-%X-%X",
+		binary.LittleEndian.Uint32(g[0:4]),
+		binary.LittleEndian.Uint16(g[4:6]),
+		binary.LittleEndian.Uint16(g[6:8]),
+		g[8:10], g[10:16])
+}
+
+// Vendor GUIDs of the EFI configuration table entries pointing to the SMBIOS
+// entry points, defined in the UEFI specification 4.6.
+var (
+	SMBIOSTableGUID  = GUID{0x31, 0x2d, 0x9d, 0xeb, 0x88, 0x2d, 0xd3, 0x11, 0x9a, 0x16, 0x00, 0x90, 0x27, 0x3f, 0xc1, 0x4d}
+	SMBIOS3TableGUID = GUID{0x44, 0x15, 0xfd, 0xf2, 0x94, 0x97, 0x2c, 0x4a, 0x99, 0x2e, 0xe5, 0xbb, 0xcf, 0x20, 0xe3, 0x94}
+)
+
+// Errors for the EFI System Table.
+var (
+	ErrInvalidEFISystemTable = errors.New("invalid EFI System Table")
+	ErrNoSMBIOSInEFI         = errors.New("no SMBIOS entry point in EFI configuration table")
+)
+
+const (
+	efiSystemTableSignature = 0x5453595320494249 // "IBI SYST"
+
+	// Sizes of the EFI System Table, and offsets of NumberOfTableEntries
+	// and ConfigurationTable, for 32 and 64-bit firmware.
+	efiSystemTableSize32 = 72
+	efiSystemTableSize64 = 120
+	efiNumTablesOff32    = 64
+	efiNumTablesOff64    = 104
+
+	// Sanity limit for NumberOfTableEntries.
+	efiMaxConfigTables = 1024
+)
+
+// EFIConfigTable is an entry of the EFI configuration table: a vendor GUID
+// and the physical address of the vendor table.
+type EFIConfigTable struct {
+	GUID    GUID
+	Address uint64
+}
+
+// ParseEFIConfigTables reads the EFI System Table at address systab in mem,
+// an image of physical memory, and returns the entries of its configuration
+// table. Both 32 and 64-bit system tables are supported, they are told apart
+// by the header size.
+func ParseEFIConfigTables(mem io.ReaderAt, systab int64) ([]EFIConfigTable, error) {
+	var hdr struct {
+		Signature  uint64
+		Revision   uint32
+		HeaderSize uint32
+	}
+	if err := binary.Read(io.NewSectionReader(mem, systab, 16), binary.LittleEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("error reading EFI System Table at 0x%x: %w", systab, convertUnexpectedEOF(err))
+	}
+	if hdr.Signature != efiSystemTableSignature {
+		return nil, fmt.Errorf("%w: bad signature 0x%016x at 0x%x", ErrInvalidEFISystemTable, hdr.Signature, systab)
+	}
+
+	var ptrSize, numOff int64
+	switch hdr.HeaderSize {
+	case efiSystemTableSize32:
+		ptrSize, numOff = 4, efiNumTablesOff32
+	case efiSystemTableSize64:
+		ptrSize, numOff = 8, efiNumTablesOff64
+	default:
+		return nil, fmt.Errorf("%w: unexpected header size %d", ErrInvalidEFISystemTable, hdr.HeaderSize)
+	}
+	readPtr := func(off int64) (uint64, error) {
+		b := make([]byte, ptrSize)
+		if _, err := mem.ReadAt(b, off); err != nil {
+			return 0, convertUnexpectedEOF(err)
+		}
+		if ptrSize == 4 {
+			return uint64(binary.LittleEndian.Uint32(b)), nil
+		}
+		return binary.LittleEndian.Uint64(b), nil
+	}
+
+	num, err := readPtr(systab + numOff)
+	if err != nil {
+		return nil, fmt.Errorf("error reading EFI System Table: %w", err)
+	}
+	if num > efiMaxConfigTables {
+		return nil, fmt.Errorf("%w: %d configuration table entries", ErrInvalidEFISystemTable, num)
+	}
+	addr, err := readPtr(systab + numOff + ptrSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading EFI System Table: %w", err)
+	}
+
+	// Each entry is a GUID followed by a pointer.
+	entrySize := int64(len(GUID{})) + ptrSize
+	tables := make([]EFIConfigTable, num)
+	for i := range tables {
+		off := int64(addr) + int64(i)*entrySize
+		if _, err := mem.ReadAt(tables[i].GUID[:], off); err != nil {
+			return nil, fmt.Errorf("error reading EFI configuration table: %w", convertUnexpectedEOF(err))
+		}
+		if tables[i].Address, err = readPtr(off + int64(len(GUID{}))); err != nil {
+			return nil, fmt.Errorf("error reading EFI configuration table: %w", err)
+		}
+	}
+	return tables, nil
+}
+
+// EntryBaseFromEFISystemTable finds the SMBIOS entry point address in the
+// configuration table of the EFI System Table at address systab in mem. The
+// SMBIOS 3 entry point is preferred over the 32-bit one.
+//
+// The returned size is the size of the entry point structure, as with EntryBase.
+func EntryBaseFromEFISystemTable(mem io.ReaderAt, systab int64) (base int64, size int64, err error) {
+	tables, err := ParseEFIConfigTables(mem, systab)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, want := range []struct {
+		guid GUID
+		size int64
+	}{
+		{SMBIOS3TableGUID, smbios3HeaderSize},
+		{SMBIOSTableGUID, smbios2HeaderSize},
+	} {
+		for _, t := range tables {
+			if t.GUID == want.guid {
+				return int64(t.Address), want.size, nil
+			}
+		}
+	}
+	return 0, 0, ErrNoSMBIOSInEFI
+}
@@ -0,0 +1,175 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var testVendorGUID = GUID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+
+// efiImage writes an EFI System Table at systab to mem, with the
+// configuration table right after it.
+func efiImage(mem []byte, systab int, is64 bool, tables []EFIConfigTable) {
+	size, numOff, ptrSize := efiSystemTableSize32, efiNumTablesOff32, 4
+	if is64 {
+		size, numOff, ptrSize = efiSystemTableSize64, efiNumTablesOff64, 8
+	}
+	putPtr := func(off int, v uint64) {
+		if is64 {
+			binary.LittleEndian.PutUint64(mem[off:], v)
+		} else {
+			binary.LittleEndian.PutUint32(mem[off:], uint32(v))
+		}
+	}
+	binary.LittleEndian.PutUint64(mem[systab:], efiSystemTableSignature)
+	binary.LittleEndian.PutUint32(mem[systab+8:], 2<<16|70)
+	binary.LittleEndian.PutUint32(mem[systab+12:], uint32(size))
+	ct := systab + size
+	putPtr(systab+numOff, uint64(len(tables)))
+	putPtr(systab+numOff+ptrSize, uint64(ct))
+	for i, t := range tables {
+		off := ct + i*(16+ptrSize)
+		copy(mem[off:], t.GUID[:])
+		putPtr(off+16, t.Address)
+	}
+}
+
+func TestGUIDString(t *testing.T) {
+	if got, want := SMBIOSTableGUID.String(), "EB9D2D31-2D88-11D3-9A16-0090273FC14D"; got != want {
+		t.Errorf("SMBIOSTableGUID = %s, want %s", got, want)
+	}
+	if got, want := SMBIOS3TableGUID.String(), "F2FD1544-9794-4A2C-992E-E5BBCF20E394"; got != want {
+		t.Errorf("SMBIOS3TableGUID = %s, want %s", got, want)
+	}
+}
+
+func TestParseEFIConfigTables(t *testing.T) {
+	tables := []EFIConfigTable{
+		{testVendorGUID, 0x1234},
+		{SMBIOSTableGUID, 0x7000},
+		{SMBIOS3TableGUID, 0xfedcba9876},
+	}
+	for _, is64 := range []bool{false, true} {
+		mem := make([]byte, 0x1000)
+		efiImage(mem, 0x800, is64, tables)
+		want := tables
+		if !is64 {
+			// Pointers are truncated to 32 bits.
+			want = append([]EFIConfigTable{}, tables...)
+			want[2].Address &= 0xffffffff
+		}
+		got, err := ParseEFIConfigTables(bytes.NewReader(mem), 0x800)
+		if err != nil {
+			t.Fatalf("64-bit %v: ParseEFIConfigTables() = %v", is64, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("64-bit %v: ParseEFIConfigTables() = %v, want %v", is64, got, want)
+		}
+	}
+}
+
+func TestParseEFIConfigTablesErrors(t *testing.T) {
+	mem := make([]byte, 0x1000)
+	efiImage(mem, 0x100, true, []EFIConfigTable{{SMBIOSTableGUID, 0x7000}})
+
+	bad := append([]byte{}, mem...)
+	bad[0x100] = 'X'
+	if _, err := ParseEFIConfigTables(bytes.NewReader(bad), 0x100); !errors.Is(err, ErrInvalidEFISystemTable) {
+		t.Errorf("bad signature: ParseEFIConfigTables() = %v, want %v", err, ErrInvalidEFISystemTable)
+	}
+
+	bad = append([]byte{}, mem...)
+	binary.LittleEndian.PutUint32(bad[0x10c:], 100)
+	if _, err := ParseEFIConfigTables(bytes.NewReader(bad), 0x100); !errors.Is(err, ErrInvalidEFISystemTable) {
+		t.Errorf("bad header size: ParseEFIConfigTables() = %v, want %v", err, ErrInvalidEFISystemTable)
+	}
+
+	bad = append([]byte{}, mem...)
+	binary.LittleEndian.PutUint64(bad[0x100+efiNumTablesOff64:], 1<<32)
+	if _, err := ParseEFIConfigTables(bytes.NewReader(bad), 0x100); !errors.Is(err, ErrInvalidEFISystemTable) {
+		t.Errorf("too many tables: ParseEFIConfigTables() = %v, want %v", err, ErrInvalidEFISystemTable)
+	}
+
+	bad = append([]byte{}, mem...)
+	binary.LittleEndian.PutUint64(bad[0x100+efiNumTablesOff64+8:], 0x2000)
+	if _, err := ParseEFIConfigTables(bytes.NewReader(bad), 0x100); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("table beyond memory: ParseEFIConfigTables() = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	if _, err := ParseEFIConfigTables(bytes.NewReader(mem), 0xff8); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("system table beyond memory: ParseEFIConfigTables() = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestEntryBaseFromEFISystemTable(t *testing.T) {
+	for _, tt := range []struct {
+		tables []EFIConfigTable
+		base   int64
+		size   int64
+		err    error
+	}{
+		{
+			tables: []EFIConfigTable{{testVendorGUID, 0x1234}, {SMBIOSTableGUID, 0x7000}, {SMBIOS3TableGUID, 0x8000}},
+			base:   0x8000,
+			size:   smbios3HeaderSize,
+		},
+		{
+			tables: []EFIConfigTable{{SMBIOSTableGUID, 0x7000}},
+			base:   0x7000,
+			size:   smbios2HeaderSize,
+		},
+		{
+			tables: []EFIConfigTable{{testVendorGUID, 0x1234}},
+			err:    ErrNoSMBIOSInEFI,
+		},
+		{
+			err: ErrNoSMBIOSInEFI,
+		},
+	} {
+		mem := make([]byte, 0x1000)
+		efiImage(mem, 0x10, true, tt.tables)
+		base, size, err := EntryBaseFromEFISystemTable(bytes.NewReader(mem), 0x10)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("EntryBaseFromEFISystemTable(%v) = %v, want %v", tt.tables, err, tt.err)
+		}
+		if base != tt.base || size != tt.size {
+			t.Errorf("EntryBaseFromEFISystemTable(%v) = 0x%x, 0x%x, want 0x%x, 0x%x", tt.tables, base, size, tt.base, tt.size)
+		}
+	}
+}
+
+func TestEFISystemTableSource(t *testing.T) {
+	e, table := testMemTables(t)
+	e.StructTableAddr = 0x1000
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := memImage(0x2000, 0x800, entry, 0x1000, table)
+	efiImage(mem, 0x100, true, []EFIConfigTable{{SMBIOSTableGUID, 0x800}})
+	memFile := filepath.Join(t.TempDir(), "mem")
+	if err := os.WriteFile(memFile, mem, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gotEntry, gotTable, err := EFISystemTableSource{MemPath: memFile, SystemTable: 0x100}.Read()
+	if err != nil {
+		t.Fatalf("Read() = %v", err)
+	}
+	if !bytes.Equal(gotEntry, entry) {
+		t.Errorf("Read() entry = %x, want %x", gotEntry, entry)
+	}
+	if !bytes.Equal(gotTable, table) {
+		t.Errorf("Read() table has %d bytes, want %d", len(gotTable), len(table))
+	}
+}
@@ -26,7 +26,8 @@ var (
 
 // EntryPoint is an SMBIOS entry point.
 //
-// To access detailed information, use a type assertion to *Entry32 or *Entry64.
+// To access detailed information, use a type assertion to *Entry32, *Entry64
+// or *EntryDMI.
 type EntryPoint interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
@@ -51,8 +52,9 @@ func calcChecksum(data []byte, skipIndex int) uint8 {
 }
 
 var (
-	anchor32 = []byte("_SM_")
-	anchor64 = []byte("_SM3_")
+	anchor32  = []byte("_SM_")
+	anchor64  = []byte("_SM3_")
+	anchorDMI = []byte("_DMI_")
 )
 
 // ParseEntry parses SMBIOS 32 or 64-bit entrypoint structure.
@@ -74,6 +76,10 @@ func ParseEntry(r io.Reader) (EntryPoint, error) {
 		data = make([]byte, 0x18)
 		e = &Entry64{}
 
+	case bytes.Equal(peek, anchorDMI):
+		data = make([]byte, legacyDMIHeaderSize)
+		e = &EntryDMI{}
+
 	default:
 		return nil, fmt.Errorf("%w: %x", ErrInvalidAnchor, peek[:4])
 	}
@@ -138,7 +144,7 @@ func (e *Entry32) UnmarshalBinary(data []byte) error {
 	if e.Checksum != cs {
 		return fmt.Errorf("checksum mismatch: 0x%02x vs 0x%02x", e.Checksum, cs)
 	}
-	if !bytes.Equal(e.IntAnchor[:], []byte("_DMI_")) {
+	if !bytes.Equal(e.IntAnchor[:], anchorDMI) {
 		return fmt.Errorf("invalid intermediate anchor string %q", string(e.Anchor[:]))
 	}
 	intCs := calcChecksum(data[0x10:0x1f], 5)
@@ -222,3 +228,61 @@ func (e *Entry64) MarshalBinary() ([]byte, error) {
 	data[5] = calcChecksum(data, 5)
 	return data, nil
 }
+
+// EntryDMI is the legacy DMI 2.0 entry point structure, found on systems that
+// predate SMBIOS 2.1 without an SMBIOS entry point around it. It has the same
+// layout as the intermediate part of Entry32, starting at IntAnchor.
+type EntryDMI struct {
+	Anchor            [5]uint8
+	Checksum          uint8
+	StructTableLength uint16
+	StructTableAddr   uint32
+	NumberOfStructs   uint16
+	BCDRevision       uint8
+}
+
+var _ EntryPoint = &EntryDMI{}
+
+// Table returns the physical address of the table, and its size.
+func (e *EntryDMI) Table() (address, size int) {
+	return int(e.StructTableAddr), int(e.StructTableLength)
+}
+
+// Version returns the version associated with the table structure, from the
+// BCD revision.
+func (e *EntryDMI) Version() (major, minor, rev int) {
+	return int(e.BCDRevision >> 4), int(e.BCDRevision & 0x0f), 0
+}
+
+// String returns a summary of the DMI version.
+func (e *EntryDMI) String() string {
+	major, minor, _ := e.Version()
+	return fmt.Sprintf("Legacy DMI %d.%d", major, minor)
+}
+
+// UnmarshalBinary unmarshals the legacy DMI entry point structure from binary data.
+func (e *EntryDMI) UnmarshalBinary(data []byte) error {
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, e); err != nil {
+		return err
+	}
+	if !bytes.Equal(e.Anchor[:], anchorDMI) {
+		return fmt.Errorf("%w: %q", ErrInvalidAnchor, string(e.Anchor[:]))
+	}
+	cs := calcChecksum(data[:legacyDMIHeaderSize], 5)
+	if e.Checksum != cs {
+		return fmt.Errorf("checksum mismatch: 0x%02x vs 0x%02x", e.Checksum, cs)
+	}
+	return nil
+}
+
+// MarshalBinary marshals the legacy DMI entry point structure to binary data.
+func (e *EntryDMI) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, e); err != nil {
+		return nil, err
+	}
+	// Adjust checksum.
+	data := buf.Bytes()
+	data[5] = calcChecksum(data, 5)
+	return data, nil
+}
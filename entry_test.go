@@ -118,6 +118,32 @@ func TestParseEntry(t *testing.T) {
 				StructTableAddr: 0xFFFFFFFFFFFFFFFF,
 			},
 		},
+		{
+			b: []byte{
+				'_', 'D', 'M', 'I', '_',
+				0xE9, // checksum
+				0x40, 0x01,
+				0x00, 0x00, 0x0E, 0x00,
+				0x10, 0x00,
+				0x20,
+			},
+			want: &EntryDMI{
+				Anchor:            [5]byte{95, 68, 77, 73, 95},
+				Checksum:          0xE9,
+				StructTableLength: 0x0140,
+				StructTableAddr:   0x000E0000,
+				NumberOfStructs:   0x0010,
+				BCDRevision:       0x20,
+			},
+		},
+		{
+			b: []byte{
+				'_', 'D', 'M', 'I', '_',
+				0xE9, // checksum
+				0x40, 0x01,
+			},
+			err: io.ErrUnexpectedEOF,
+		},
 	} {
 		t.Run("", func(t *testing.T) {
 			got, err := ParseEntry(bytes.NewReader(tt.b))
@@ -194,6 +220,24 @@ func TestMarshalEntry(t *testing.T) {
 				StructTableAddr: 0xFFFFFFFFFFFFFFFF,
 			},
 		},
+		{
+			want: []byte{
+				'_', 'D', 'M', 'I', '_',
+				0xE9, // checksum
+				0x40, 0x01,
+				0x00, 0x00, 0x0E, 0x00,
+				0x10, 0x00,
+				0x20,
+			},
+			e: &EntryDMI{
+				Anchor:            [5]byte{95, 68, 77, 73, 95},
+				Checksum:          0xE9,
+				StructTableLength: 0x0140,
+				StructTableAddr:   0x000E0000,
+				NumberOfStructs:   0x0010,
+				BCDRevision:       0x20,
+			},
+		},
 	} {
 		t.Run("", func(t *testing.T) {
 			got, err := tt.e.MarshalBinary()
@@ -206,3 +250,26 @@ func TestMarshalEntry(t *testing.T) {
 		})
 	}
 }
+
+func TestEntryDMI(t *testing.T) {
+	b := []byte{'_', 'D', 'M', 'I', '_', 0xE9, 0x40, 0x01, 0x00, 0x00, 0x0E, 0x00, 0x10, 0x00, 0x21}
+	b[5] = calcChecksum(b, 5)
+	var e EntryDMI
+	if err := e.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary() = %v", err)
+	}
+	if major, minor, rev := e.Version(); major != 2 || minor != 1 || rev != 0 {
+		t.Errorf("Version() = %d.%d.%d, want 2.1.0", major, minor, rev)
+	}
+	if addr, size := e.Table(); addr != 0xe0000 || size != 0x140 {
+		t.Errorf("Table() = 0x%x, 0x%x, want 0xe0000, 0x140", addr, size)
+	}
+	if got, want := e.String(), "Legacy DMI 2.1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	b[5]++
+	if err := e.UnmarshalBinary(b); err == nil {
+		t.Errorf("UnmarshalBinary() with bad checksum = nil, want error")
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+const (
+	// imageMaxStrings is the maximum size of the string set of a structure
+	// that is considered plausible when searching a firmware image.
+	imageMaxStrings = 0x1000
+
+	// imageMaxTable is the maximum size of a table found by searching.
+	imageMaxTable = 1 << 20
+
+	// imageMaxCandidates is the maximum number of end-of-table structures
+	// that a table found by searching may end with.
+	imageMaxCandidates = 16
+)
+
+type imageEntry struct {
+	entry EntryPoint
+	data  []byte
+}
+
+// findImageEntries returns all entry points with valid checksums in img.
+func findImageEntries(img []byte) []imageEntry {
+	var res []imageEntry
+	for _, a := range []struct {
+		anchor []byte
+		size   int
+		entry  func() EntryPoint
+	}{
+		{anchor64, smbios3HeaderSize, func() EntryPoint { return &Entry64{} }},
+		{anchor32, smbios2HeaderSize, func() EntryPoint { return &Entry32{} }},
+		{anchorDMI, legacyDMIHeaderSize, func() EntryPoint { return &EntryDMI{} }},
+	} {
+		for off := 0; ; {
+			i := bytes.Index(img[off:], a.anchor)
+			if i < 0 {
+				break
+			}
+			off += i
+			if off+a.size <= len(img) {
+				e := a.entry()
+				if err := e.UnmarshalBinary(img[off : off+a.size]); err == nil {
+					res = append(res, imageEntry{entry: e, data: img[off : off+a.size]})
+				}
+			}
+			off++
+		}
+	}
+	return res
+}
+
+// imageTableEnd walks the structures of data and returns the offset just past
+// the end-of-table structure, or -1 if data does not start with a plausible
+// sequence of structures ending in one. If exact is non-zero, a sequence
+// without end-of-table structure of exactly that size is accepted as well, as
+// written by some legacy BIOSes. If printable is true, strings must only
+// contain printable ASCII characters.
+func imageTableEnd(data []byte, exact int, printable bool) int {
+	for off := 0; off+headerLen <= len(data); {
+		if exact > 0 && off == exact {
+			return off
+		}
+		tt, length := TableType(data[off]), int(data[off+1])
+		if length < headerLen || off+length > len(data) {
+			return -1
+		}
+		strs := data[off+length : min(len(data), off+length+imageMaxStrings)]
+		i := bytes.Index(strs, []byte{0, 0})
+		if i < 0 {
+			return -1
+		}
+		if printable {
+			for _, c := range strs[:i] {
+				if c != 0 && (c < ' ' || c > '~') {
+					return -1
+				}
+			}
+		}
+		off += length + i + 2
+		if tt == TableTypeEndOfTable {
+			return off
+		}
+	}
+	if exact > 0 && len(data) == exact {
+		return exact
+	}
+	return -1
+}
+
+// imageTableOffsets returns the offsets in an image of size n that the table
+// address of e may correspond to: the address itself, and the address if the
+// image is mapped to the top of the 32-bit address space, as on x86.
+func imageTableOffsets(e EntryPoint, n int) []int {
+	addr, _ := e.Table()
+	offs := []int{addr}
+	if top := int64(addr) - (1<<32 - int64(n)); top >= 0 && top < int64(n) {
+		offs = append(offs, int(top))
+	}
+	return offs
+}
+
+// tableSequenceStarts returns, for each offset in data, whether the structures
+// starting there, with printable strings, end with an end-of-table structure
+// right at the end of data, i.e. whether imageTableEnd(data[off:], 0, true)
+// is len(data)-off. It walks data once, backwards.
+func tableSequenceStarts(data []byte) []bool {
+	n := len(data)
+	// nuls[i] is the offset of the first double NUL at or after i, and
+	// nonPrint[i] that of the first byte that is not allowed in a string.
+	nuls := make([]int32, n+1)
+	nonPrint := make([]int32, n+1)
+	nuls[n], nonPrint[n] = int32(n), int32(n)
+	for i := n - 1; i >= 0; i-- {
+		nuls[i], nonPrint[i] = nuls[i+1], nonPrint[i+1]
+		if i+1 < n && data[i] == 0 && data[i+1] == 0 {
+			nuls[i] = int32(i)
+		}
+		if c := data[i]; c != 0 && (c < ' ' || c > '~') {
+			nonPrint[i] = int32(i)
+		}
+	}
+	ok := make([]bool, n+1)
+	for off := n - headerLen; off >= 0; off-- {
+		length := int(data[off+1])
+		strs := off + length
+		if length < headerLen || strs > n {
+			continue
+		}
+		i := int(nuls[strs])
+		if i+1 >= n || i+2 > strs+imageMaxStrings || int(nonPrint[strs]) < i {
+			continue
+		}
+		if next := i + 2; TableType(data[off]) == TableTypeEndOfTable {
+			ok[off] = next == n
+		} else {
+			ok[off] = ok[next]
+		}
+	}
+	return ok[:n]
+}
+
+// findTableSequence searches img for the longest plausible structure table
+// ending in an end-of-table structure without strings. The table size given by
+// e, if the table is at most that size, is tried first. Only the first
+// imageMaxCandidates end-of-table structures are considered.
+func findTableSequence(img []byte, e EntryPoint) []byte {
+	_, size := e.Table()
+	eot := []byte{byte(TableTypeEndOfTable), headerLen}
+	for p, n := 0, 0; n < imageMaxCandidates; p++ {
+		i := bytes.Index(img[p:], eot)
+		if i < 0 {
+			return nil
+		}
+		p += i
+		end := p + headerLen + 2
+		if end > len(img) || img[end-2] != 0 || img[end-1] != 0 {
+			continue
+		}
+		n++
+		lo := max(0, end-imageMaxTable)
+		ok := tableSequenceStarts(img[lo:end])
+		if start := end - size; start >= lo && start < p && ok[start-lo] {
+			return img[start:end]
+		}
+		for start := lo; start < p; start++ {
+			if ok[start-lo] {
+				return img[start:end]
+			}
+		}
+	}
+	return nil
+}
+
+// ReadImage searches a raw firmware image, such as a .rom or .fd file, for an
+// SMBIOS entry point and structure table, and returns their raw data.
+//
+// Entry points are found by their anchor and validated by checksum. The table
+// is expected at the address given in the entry point, taken either as an
+// offset into the image or as an address with the image mapped right below
+// 4 GiB. If the table was relocated, the image is searched for the longest
+// plausible sequence of structures with printable strings that ends with an
+// end-of-table structure. If no table is found, ErrTableNotFound is returned.
+func ReadImage(img []byte) (entryData, tableData []byte, err error) {
+	entries := findImageEntries(img)
+	if len(entries) == 0 {
+		return nil, nil, ErrAnchorNotFound
+	}
+	for _, ie := range entries {
+		_, size := ie.entry.Table()
+		exact := 0
+		if _, ok := ie.entry.(*Entry64); !ok {
+			exact = size
+		}
+		for _, off := range imageTableOffsets(ie.entry, len(img)) {
+			if off < 0 || off >= len(img) {
+				continue
+			}
+			data := img[off:min(len(img), off+size)]
+			if end := imageTableEnd(data, exact, false); end > 0 {
+				return ie.data, data[:end], nil
+			}
+		}
+	}
+	for _, ie := range entries {
+		if data := findTableSequence(img, ie.entry); data != nil {
+			return ie.data, data, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: no structure table in image", ErrTableNotFound)
+}
+
+// FromImage parses the SMBIOS entry point and tables found in a raw firmware
+// image read from r. See ReadImage.
+func FromImage(r io.Reader) (EntryPoint, Tables, error) {
+	img, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	entryData, tableData, err := ReadImage(img)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := ParseEntry(bytes.NewReader(entryData))
+	if err != nil {
+		return nil, nil, err
+	}
+	tables, err := ParseTables(bytes.NewReader(tableData))
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, tables, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// firmwareImage returns a pseudo-random image of the given size with the
+// entry point and table data at the given offsets.
+func firmwareImage(size int, entryOff int, entry []byte, tableOff int, table []byte) []byte {
+	img := make([]byte, size)
+	rand.New(rand.NewSource(1)).Read(img)
+	copy(img[entryOff:], entry)
+	copy(img[tableOff:], table)
+	return img
+}
+
+func TestReadImage(t *testing.T) {
+	const size = 0x10000
+	e32, table := testMemTables(t)
+
+	for _, tt := range []struct {
+		name     string
+		addr     uint32
+		tableOff int
+	}{
+		{"image offset", 0x4000, 0x4000},
+		{"mapped below 4G", 1<<32 - size + 0x5000, 0x5000},
+		{"relocated", 0x7fe00000, 0x6003},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := *e32
+			e.StructTableAddr = tt.addr
+			entry, err := e.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			img := firmwareImage(size, 0x1230, entry, tt.tableOff, table)
+
+			gotEntry, gotTable, err := ReadImage(img)
+			if err != nil {
+				t.Fatalf("ReadImage() = %v", err)
+			}
+			if !bytes.Equal(gotEntry, entry) {
+				t.Errorf("ReadImage() entry = %x, want %x", gotEntry, entry)
+			}
+			if !bytes.Equal(gotTable, table) {
+				t.Errorf("ReadImage() table has %d bytes, want %d", len(gotTable), len(table))
+			}
+		})
+	}
+}
+
+func TestReadImage64(t *testing.T) {
+	_, table := testMemTables(t)
+	e := &Entry64{
+		Anchor:          [5]uint8{'_', 'S', 'M', '3', '_'},
+		Length:          smbios3HeaderSize,
+		MajorVersion:    3,
+		MinorVersion:    2,
+		StructMaxSize:   0x2000,
+		StructTableAddr: 0xdead0000,
+	}
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A corrupted copy of the entry point must be skipped.
+	bad := append([]byte{}, entry...)
+	bad[10]++
+	img := firmwareImage(0x10000, 0x9000, entry, 0x2001, table)
+	copy(img[0x100:], bad)
+
+	got, tables, err := FromImage(bytes.NewReader(img))
+	if err != nil {
+		t.Fatalf("FromImage() = %v", err)
+	}
+	if got.String() != "SMBIOS 3.2.0" {
+		t.Errorf("FromImage() entry = %s, want SMBIOS 3.2.0", got)
+	}
+	if len(tables) == 0 || tables[len(tables)-1].Type != TableTypeEndOfTable {
+		t.Errorf("FromImage() = %d tables, want tables ending in end-of-table", len(tables))
+	}
+}
+
+func TestReadImageErrors(t *testing.T) {
+	e32, _ := testMemTables(t)
+	entry, err := e32.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := ReadImage(firmwareImage(0x1000, 0, nil, 0, nil)); !errors.Is(err, ErrAnchorNotFound) {
+		t.Errorf("ReadImage() = %v, want %v", err, ErrAnchorNotFound)
+	}
+	if _, _, err := ReadImage(firmwareImage(0x1000, 0x10, entry, 0, nil)); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("ReadImage() = %v, want %v", err, ErrTableNotFound)
+	}
+}
+
+func TestTableSequenceStarts(t *testing.T) {
+	_, table := testMemTables(t)
+	img := firmwareImage(0x3000, 0, nil, 0x1003, table)
+	data := img[:0x1003+len(table)]
+	ok := tableSequenceStarts(data)
+	for off := range data {
+		want := imageTableEnd(data[off:], 0, true) == len(data)-off
+		if ok[off] != want {
+			t.Errorf("tableSequenceStarts()[0x%x] = %v, want %v", off, ok[off], want)
+		}
+	}
+	if !ok[0x1003] {
+		t.Errorf("tableSequenceStarts()[0x1003] = false, want true")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// memChunkSize is the size in which a table with only a maximum size is read.
+const memChunkSize = 4096
+
+// ReadMemory reads the entry point at base and the structure table it points
+// to from mem, an image of physical memory such as /dev/mem. It returns the
+// raw entry point and table data, as found in sysfs.
+//
+// The 64-bit entry point only gives the maximum size of the table. The table
+// is read up to and including the end-of-table structure, or up to the end of
+// mem, whichever comes first.
+func ReadMemory(mem io.ReaderAt, base int64) (entryData, tableData []byte, err error) {
+	e, err := ParseEntry(io.NewSectionReader(mem, base, smbios2HeaderSize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing entry point structure at 0x%x: %w", base, err)
+	}
+	var entrySize int
+	switch e.(type) {
+	case *Entry64:
+		entrySize = smbios3HeaderSize
+	case *EntryDMI:
+		entrySize = legacyDMIHeaderSize
+	default:
+		entrySize = smbios2HeaderSize
+	}
+	entryData = make([]byte, entrySize)
+	if _, err := mem.ReadAt(entryData, base); err != nil {
+		return nil, nil, fmt.Errorf("error reading entry point structure: %w", err)
+	}
+
+	addr, size := e.Table()
+	if _, ok := e.(*Entry64); !ok {
+		tableData = make([]byte, size)
+		if _, err := mem.ReadAt(tableData, int64(addr)); err != nil {
+			return nil, nil, fmt.Errorf("error reading table at 0x%x: %w", addr, convertUnexpectedEOF(err))
+		}
+		return entryData, tableData, nil
+	}
+
+	// Read in chunks so that a bogus maximum size does not lead to a huge
+	// allocation.
+	buf := make([]byte, memChunkSize)
+	for off := 0; off < size; {
+		n, err := mem.ReadAt(buf[:min(len(buf), size-off)], int64(addr+off))
+		tableData = append(tableData, buf[:n]...)
+		if end := tableEnd(tableData); end >= 0 {
+			return entryData, tableData[:end], nil
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading table at 0x%x: %w", addr+off, err)
+		}
+		off += n
+	}
+	return entryData, tableData, nil
+}
+
+// tableEnd returns the offset in data just past the end-of-table structure,
+// or -1 if data does not contain a complete end-of-table structure.
+func tableEnd(data []byte) int {
+	for off := 0; off+headerLen <= len(data); {
+		tt, length := TableType(data[off]), int(data[off+1])
+		if length < headerLen || off+length > len(data) {
+			return -1
+		}
+		// The string set is terminated by two NULs.
+		i := bytes.Index(data[off+length:], []byte{0, 0})
+		if i < 0 {
+			return -1
+		}
+		off += length + i + 2
+		if tt == TableTypeEndOfTable {
+			return off
+		}
+	}
+	return -1
+}
+
+// FromMemoryAt parses the entry point at base in mem, an image of physical
+// memory such as /dev/mem, and the tables it points to.
+func FromMemoryAt(mem io.ReaderAt, base int64) (EntryPoint, Tables, error) {
+	entryData, tableData, err := ReadMemory(mem, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := ParseEntry(bytes.NewReader(entryData))
+	if err != nil {
+		return nil, nil, err
+	}
+	tables, err := ParseTables(bytes.NewReader(tableData))
+	if err != nil {
+		return nil, nil, err
+	}
+	return e, tables, nil
+}
+
+// FromMemory reads the SMBIOS entry point and tables from mem, an image of
+// physical memory such as /dev/mem. Only mem is consulted: the entry point is
+// found by scanning its F0000 segment. Use FromMemoryAt when the entry point
+// address is known, e.g. from the host's EFI System Table.
+func FromMemory(mem io.ReaderAt) (EntryPoint, Tables, error) {
+	base, _, err := getMemBase(mem, 0xf0000, 0x100000)
+	if err != nil {
+		return nil, nil, err
+	}
+	return FromMemoryAt(mem, base)
+}
@@ -0,0 +1,160 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+)
+
+// testMemTables returns the entry point and table data of the test binary.
+func testMemTables(t *testing.T) (*Entry32, []byte) {
+	t.Helper()
+	data, err := os.ReadFile(testbinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Entry32
+	if err := e.UnmarshalBinary(data[:smbios2HeaderSize]); err != nil {
+		t.Fatal(err)
+	}
+	return &e, data[32:]
+}
+
+// memImage returns a memory image of the given size with entry at base and
+// table at addr. All other bytes are 0xff.
+func memImage(size int, base int64, entry []byte, addr int, table []byte) []byte {
+	mem := bytes.Repeat([]byte{0xff}, size)
+	copy(mem[base:], entry)
+	copy(mem[addr:], table)
+	return mem
+}
+
+func TestReadMemory32(t *testing.T) {
+	e, table := testMemTables(t)
+	e.StructTableAddr = 0x1000
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := memImage(0x2000, 0x10, entry, 0x1000, table)
+
+	gotEntry, gotTable, err := ReadMemory(bytes.NewReader(mem), 0x10)
+	if err != nil {
+		t.Fatalf("ReadMemory() = %v", err)
+	}
+	if !bytes.Equal(gotEntry, entry) {
+		t.Errorf("ReadMemory() entry = %x, want %x", gotEntry, entry)
+	}
+	if !bytes.Equal(gotTable, table) {
+		t.Errorf("ReadMemory() table = %x, want %x", gotTable, table)
+	}
+
+	// Table beyond end of memory.
+	if _, _, err := ReadMemory(bytes.NewReader(mem[:0x1800]), 0x10); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("ReadMemory() = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	// No entry point.
+	if _, _, err := ReadMemory(bytes.NewReader(mem), 0x11); !errors.Is(err, ErrInvalidAnchor) {
+		t.Errorf("ReadMemory() = %v, want %v", err, ErrInvalidAnchor)
+	}
+}
+
+func TestReadMemory64(t *testing.T) {
+	_, table := testMemTables(t)
+	// Duplicate the structures so that the table spans several chunks.
+	eot := table[len(table)-6:]
+	if eot[0] != byte(TableTypeEndOfTable) {
+		t.Fatalf("test table does not end with end-of-table structure: %x", eot)
+	}
+	structs := table[:len(table)-len(eot)]
+	table = append(append(append([]byte{}, structs...), structs...), eot...)
+
+	for _, tt := range []struct {
+		name    string
+		maxSize uint32
+		memSize int
+		want    []byte
+	}{
+		{"exact", uint32(len(table)), 0x4000, table},
+		{"max size larger than table", 0x2000, 0x4000, table},
+		{"max size beyond end of memory", 0xffffffff, 0x2000 + len(table), table},
+		{"max size smaller than table", 0x1000, 0x4000, table[:0x1000]},
+		{"no end of table", 0x3000, 0x2000 + len(table) - len(eot), table[:len(table)-len(eot)]},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Entry64{
+				Anchor:          [5]uint8{'_', 'S', 'M', '3', '_'},
+				Length:          smbios3HeaderSize,
+				MajorVersion:    3,
+				MinorVersion:    2,
+				Revision:        1,
+				StructMaxSize:   tt.maxSize,
+				StructTableAddr: 0x2000,
+			}
+			entry, err := e.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			mem := memImage(tt.memSize, 0x100, entry, 0x2000, table)
+
+			gotEntry, gotTable, err := ReadMemory(bytes.NewReader(mem), 0x100)
+			if err != nil {
+				t.Fatalf("ReadMemory() = %v", err)
+			}
+			if !bytes.Equal(gotEntry, entry) {
+				t.Errorf("ReadMemory() entry = %x, want %x", gotEntry, entry)
+			}
+			if !bytes.Equal(gotTable, tt.want) {
+				t.Errorf("ReadMemory() table has %d bytes, want %d", len(gotTable), len(tt.want))
+			}
+		})
+	}
+}
+
+func TestFromMemoryAt(t *testing.T) {
+	e, table := testMemTables(t)
+	e.StructTableAddr = 0x1000
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := memImage(0x2000, 0x10, entry, 0x1000, table)
+
+	got, tables, err := FromMemoryAt(bytes.NewReader(mem), 0x10)
+	if err != nil {
+		t.Fatalf("FromMemoryAt() = %v", err)
+	}
+	if got.String() != "SMBIOS 2.7" {
+		t.Errorf("FromMemoryAt() entry = %s, want SMBIOS 2.7", got)
+	}
+	if len(tables) != int(e.NumberOfStructs) {
+		t.Errorf("FromMemoryAt() = %d tables, want %d", len(tables), e.NumberOfStructs)
+	}
+}
+
+func TestFromMemory(t *testing.T) {
+	e, table := testMemTables(t)
+	e.StructTableAddr = 0x1000
+	entry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mem := memImage(0x100000, 0xf0010, entry, 0x1000, table)
+
+	got, tables, err := FromMemory(bytes.NewReader(mem))
+	if err != nil {
+		t.Fatalf("FromMemory() = %v", err)
+	}
+	if got.String() != "SMBIOS 2.7" {
+		t.Errorf("FromMemory() entry = %s, want SMBIOS 2.7", got)
+	}
+	if len(tables) != int(e.NumberOfStructs) {
+		t.Errorf("FromMemory() = %d tables, want %d", len(tables), e.NumberOfStructs)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry
+
+import "fmt"
+
+// JEP106ID is a manufacturer identification code as defined in JEDEC JEP106
+// and stored by memory devices (DSP0134 7.18.8). The low byte is the number of
+// 0x7F continuation codes that precede the ID, the high byte is the ID itself.
+// Bit 7 of both bytes is an odd parity bit.
+type JEP106ID uint16
+
+// NewJEP106ID returns the ID of code in the 1-based JEP106 bank, with the
+// parity bit of the continuation count set. code must already carry its parity
+// bit, as it does in JEP106.
+func NewJEP106ID(bank int, code uint8) JEP106ID {
+	return JEP106ID(uint16(code)<<8 | uint16(oddParity(uint8(bank-1))))
+}
+
+// LookupJEP106 returns the ID of the manufacturer with the given name, which is
+// either its JEP106 name or any spelling NormalizeVendor knows. The comparison
+// ignores case.
+func LookupJEP106(name string) (JEP106ID, bool) {
+	return jep106.lookup(name)
+}
+
+// Bank returns the 1-based JEP106 bank of the ID.
+func (id JEP106ID) Bank() int {
+	return int(id&0x7f) + 1
+}
+
+// Code returns the ID within its bank, including the parity bit.
+func (id JEP106ID) Code() uint8 {
+	return uint8(id >> 8)
+}
+
+// Name returns the JEP106 name of the manufacturer, or an empty string if the
+// ID is not known.
+func (id JEP106ID) Name() string {
+	// The parity bit of the continuation count is not always set.
+	return jep106.name(NewJEP106ID(id.Bank(), id.Code()))
+}
+
+func (id JEP106ID) String() string {
+	if id == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("Bank %d, Hex 0x%02X", id.Bank(), id.Code())
+}
+
+// oddParity returns v with bit 7 set so that the byte has an odd number of set bits.
+func oddParity(v uint8) uint8 {
+	v &= 0x7f
+	n := 0
+	for b := v; b != 0; b &= b - 1 {
+		n++
+	}
+	if n%2 == 0 {
+		v |= 0x80
+	}
+	return v
+}
+
+// jep106 holds all of bank 1 and the manufacturers of memory modules and their
+// components from later banks. It is not the complete JEP106 table: IDs that
+// are not listed have no Name and print as their bank and code.
+var jep106 = &index[JEP106ID]{entries: []entry[JEP106ID]{
+	// Bank 1.
+	{NewJEP106ID(1, 0x01), "AMD"},
+	{NewJEP106ID(1, 0x02), "AMI"},
+	{NewJEP106ID(1, 0x83), "Fairchild"},
+	{NewJEP106ID(1, 0x04), "Fujitsu"},
+	{NewJEP106ID(1, 0x85), "GTE"},
+	{NewJEP106ID(1, 0x86), "Harris"},
+	{NewJEP106ID(1, 0x07), "Hitachi"},
+	{NewJEP106ID(1, 0x08), "Inmos"},
+	{NewJEP106ID(1, 0x89), "Intel"},
+	{NewJEP106ID(1, 0x8A), "I.T.T."},
+	{NewJEP106ID(1, 0x0B), "Intersil"},
+	{NewJEP106ID(1, 0x8C), "Monolithic Memories"},
+	{NewJEP106ID(1, 0x0D), "Mostek"},
+	{NewJEP106ID(1, 0x0E), "Freescale (Motorola)"},
+	{NewJEP106ID(1, 0x8F), "National"},
+	{NewJEP106ID(1, 0x10), "NEC"},
+	{NewJEP106ID(1, 0x91), "RCA"},
+	{NewJEP106ID(1, 0x92), "Raytheon"},
+	{NewJEP106ID(1, 0x13), "Conexant (Rockwell)"},
+	{NewJEP106ID(1, 0x94), "Seeq"},
+	{NewJEP106ID(1, 0x15), "NXP (Philips)"},
+	{NewJEP106ID(1, 0x16), "Synertek"},
+	{NewJEP106ID(1, 0x97), "Texas Instruments"},
+	{NewJEP106ID(1, 0x98), "Kioxia Corporation"},
+	{NewJEP106ID(1, 0x19), "Xicor"},
+	{NewJEP106ID(1, 0x1A), "Zilog"},
+	{NewJEP106ID(1, 0x9B), "Eurotechnique"},
+	{NewJEP106ID(1, 0x1C), "Mitsubishi"},
+	{NewJEP106ID(1, 0x9D), "Lucent (AT&T)"},
+	{NewJEP106ID(1, 0x9E), "Exel"},
+	{NewJEP106ID(1, 0x1F), "Atmel"},
+	{NewJEP106ID(1, 0x20), "STMicroelectronics"},
+	{NewJEP106ID(1, 0xA1), "Lattice Semi."},
+	{NewJEP106ID(1, 0xA2), "NCR"},
+	{NewJEP106ID(1, 0x23), "Wafer Scale Integration"},
+	{NewJEP106ID(1, 0xA4), "IBM"},
+	{NewJEP106ID(1, 0x25), "Tristar"},
+	{NewJEP106ID(1, 0x26), "Visic"},
+	{NewJEP106ID(1, 0xA7), "Intl. CMOS Technology"},
+	{NewJEP106ID(1, 0xA8), "SSSI"},
+	{NewJEP106ID(1, 0x29), "Microchip Technology"},
+	{NewJEP106ID(1, 0x2A), "Ricoh Ltd"},
+	{NewJEP106ID(1, 0xAB), "VLSI"},
+	{NewJEP106ID(1, 0x2C), "Micron Technology"},
+	{NewJEP106ID(1, 0xAD), "SK Hynix"},
+	{NewJEP106ID(1, 0xAE), "OKI Semiconductor"},
+	{NewJEP106ID(1, 0x2F), "ACTEL"},
+	{NewJEP106ID(1, 0xB0), "Sharp"},
+	{NewJEP106ID(1, 0x31), "Catalyst"},
+	{NewJEP106ID(1, 0x32), "Panasonic"},
+	{NewJEP106ID(1, 0xB3), "IDT"},
+	{NewJEP106ID(1, 0x34), "Cypress"},
+	{NewJEP106ID(1, 0xB5), "DEC"},
+	{NewJEP106ID(1, 0xB6), "LSI Logic"},
+	{NewJEP106ID(1, 0x37), "Zarlink (Plessey)"},
+	{NewJEP106ID(1, 0x38), "UTMC"},
+	{NewJEP106ID(1, 0xB9), "Thinking Machine"},
+	{NewJEP106ID(1, 0xBA), "Thomson CSF"},
+	{NewJEP106ID(1, 0x3B), "Integrated CMOS (Vertex)"},
+	{NewJEP106ID(1, 0xBC), "Honeywell"},
+	{NewJEP106ID(1, 0x3D), "Tektronix"},
+	{NewJEP106ID(1, 0x3E), "Oracle Corporation"},
+	{NewJEP106ID(1, 0xBF), "Silicon Storage Technology"},
+	{NewJEP106ID(1, 0x40), "ProMos/Mosel Vitelic"},
+	{NewJEP106ID(1, 0xC1), "Infineon (Siemens)"},
+	{NewJEP106ID(1, 0xC2), "Macronix"},
+	{NewJEP106ID(1, 0x43), "Xerox"},
+	{NewJEP106ID(1, 0xC4), "Plus Logic"},
+	{NewJEP106ID(1, 0x45), "Western Digital Technologies Inc"},
+	{NewJEP106ID(1, 0x46), "Elan Circuit Tech."},
+	{NewJEP106ID(1, 0xC7), "European Silicon Str."},
+	{NewJEP106ID(1, 0xC8), "Apple Computer"},
+	{NewJEP106ID(1, 0x49), "Xilinx"},
+	{NewJEP106ID(1, 0x4A), "Compaq"},
+	{NewJEP106ID(1, 0xCB), "Protocol Engines"},
+	{NewJEP106ID(1, 0x4C), "SCI"},
+	{NewJEP106ID(1, 0xCD), "Seiko Instruments"},
+	{NewJEP106ID(1, 0xCE), "Samsung"},
+	{NewJEP106ID(1, 0x4F), "I3 Design System"},
+	{NewJEP106ID(1, 0xD0), "Klic"},
+	{NewJEP106ID(1, 0x51), "Crosspoint Solutions"},
+	{NewJEP106ID(1, 0x52), "Alliance Semiconductor"},
+	{NewJEP106ID(1, 0xD3), "Tandem"},
+	{NewJEP106ID(1, 0x54), "Hewlett-Packard"},
+	{NewJEP106ID(1, 0xD5), "Integrated Silicon Solutions"},
+	{NewJEP106ID(1, 0xD6), "Brooktree"},
+	{NewJEP106ID(1, 0x57), "New Media"},
+	{NewJEP106ID(1, 0x58), "MHS Electronic"},
+	{NewJEP106ID(1, 0xD9), "Performance Semi."},
+	{NewJEP106ID(1, 0xDA), "Winbond Electronic"},
+	{NewJEP106ID(1, 0x5B), "Kawasaki Steel"},
+	{NewJEP106ID(1, 0xDC), "Bright Micro"},
+	{NewJEP106ID(1, 0x5D), "TECMAR"},
+	{NewJEP106ID(1, 0x5E), "Exar"},
+	{NewJEP106ID(1, 0xDF), "PCMCIA"},
+	{NewJEP106ID(1, 0xE0), "LG Semi (Goldstar)"},
+	{NewJEP106ID(1, 0x61), "Northern Telecom"},
+	{NewJEP106ID(1, 0x62), "Sanyo"},
+	{NewJEP106ID(1, 0xE3), "Array Microsystems"},
+	{NewJEP106ID(1, 0x64), "Crystal Semiconductor"},
+	{NewJEP106ID(1, 0xE5), "Analog Devices"},
+	{NewJEP106ID(1, 0xE6), "PMC-Sierra"},
+	{NewJEP106ID(1, 0x67), "Asparix"},
+	{NewJEP106ID(1, 0x68), "Convex Computer"},
+	{NewJEP106ID(1, 0xE9), "Quality Semiconductor"},
+	{NewJEP106ID(1, 0xEA), "Nimbus Technology"},
+	{NewJEP106ID(1, 0x6B), "Transwitch"},
+	{NewJEP106ID(1, 0xEC), "Micronas (ITT Intermetall)"},
+	{NewJEP106ID(1, 0x6D), "Cannon"},
+	{NewJEP106ID(1, 0x6E), "Altera"},
+	{NewJEP106ID(1, 0xEF), "NEXCOM"},
+	{NewJEP106ID(1, 0x70), "Qualcomm"},
+	{NewJEP106ID(1, 0xF1), "Sony"},
+	{NewJEP106ID(1, 0xF2), "Cray Research"},
+	{NewJEP106ID(1, 0x73), "AMS(Austria Micro)"},
+	{NewJEP106ID(1, 0xF4), "Vitesse"},
+	{NewJEP106ID(1, 0x75), "Aster Electronics"},
+	{NewJEP106ID(1, 0x76), "Bay Networks (Synoptic)"},
+	{NewJEP106ID(1, 0xF7), "Zentrum/ZMD"},
+	{NewJEP106ID(1, 0xF8), "TRW"},
+	{NewJEP106ID(1, 0x79), "Thesys"},
+	{NewJEP106ID(1, 0x7A), "Solbourne Computer"},
+	{NewJEP106ID(1, 0xFB), "Allied-Signal"},
+	{NewJEP106ID(1, 0x7C), "Dialog Semiconductor"},
+	{NewJEP106ID(1, 0xFD), "Media Vision"},
+	{NewJEP106ID(1, 0xFE), "Numonyx Corporation"},
+	// Bank 2.
+	{NewJEP106ID(2, 0x94), "Smart Modular"},
+	{NewJEP106ID(2, 0x98), "Kingston"},
+	{NewJEP106ID(2, 0x40), "Viking Components"},
+	{NewJEP106ID(2, 0x4F), "Transcend Information"},
+	{NewJEP106ID(2, 0x7A), "Apacer Technology"},
+	// Bank 3.
+	{NewJEP106ID(3, 0x16), "Netlist"},
+	{NewJEP106ID(3, 0x9E), "Corsair"},
+	{NewJEP106ID(3, 0xC8), "Agilent Technologies"},
+	{NewJEP106ID(3, 0xFE), "Elpida"},
+	// Bank 4.
+	{NewJEP106ID(4, 0x0B), "Nanya Technology"},
+	// Bank 5.
+	{NewJEP106ID(5, 0x43), "Ramaxel Technology"},
+	{NewJEP106ID(5, 0xB3), "Inphi Corporation"},
+	{NewJEP106ID(5, 0xCB), "A-DATA Technology"},
+	{NewJEP106ID(5, 0xCD), "G Skill Intl"},
+	{NewJEP106ID(5, 0xEF), "Team Group Inc"},
+	// Bank 6.
+	{NewJEP106ID(6, 0x02), "Patriot Memory (PDP Systems)"},
+	{NewJEP106ID(6, 0x9B), "Crucial Technology"},
+	{NewJEP106ID(6, 0x51), "Qimonda"},
+	{NewJEP106ID(6, 0x57), "AENEON"},
+	// Bank 7.
+	{NewJEP106ID(7, 0x32), "Montage Technology Group"},
+}}
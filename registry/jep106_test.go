@@ -0,0 +1,79 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry
+
+import "testing"
+
+func TestJEP106ID(t *testing.T) {
+	for _, tt := range []struct {
+		id   JEP106ID
+		bank int
+		code uint8
+		name string
+		str  string
+	}{
+		{id: 0, bank: 1, code: 0, name: "", str: "Unknown"},
+		{id: 0x2c80, bank: 1, code: 0x2c, name: "Micron Technology", str: "Bank 1, Hex 0x2C"},
+		{id: 0xce00, bank: 1, code: 0xce, name: "Samsung", str: "Bank 1, Hex 0xCE"},
+		{id: 0x9801, bank: 2, code: 0x98, name: "Kingston", str: "Bank 2, Hex 0x98"},
+		{id: 0x2c00, bank: 1, code: 0x2c, name: "Micron Technology", str: "Bank 1, Hex 0x2C"},
+		{id: 0x0b83, bank: 4, code: 0x0b, name: "Nanya Technology", str: "Bank 4, Hex 0x0B"},
+		{id: 0x3206, bank: 7, code: 0x32, name: "Montage Technology Group", str: "Bank 7, Hex 0x32"},
+		{id: 0x9e02, bank: 3, code: 0x9e, name: "Corsair", str: "Bank 3, Hex 0x9E"},
+		{id: 0x1602, bank: 3, code: 0x16, name: "Netlist", str: "Bank 3, Hex 0x16"},
+		{id: 0x4304, bank: 5, code: 0x43, name: "Ramaxel Technology", str: "Bank 5, Hex 0x43"},
+		{id: 0x0285, bank: 6, code: 0x02, name: "Patriot Memory (PDP Systems)", str: "Bank 6, Hex 0x02"},
+		{id: 0x7a01, bank: 2, code: 0x7a, name: "Apacer Technology", str: "Bank 2, Hex 0x7A"},
+		{id: 0x1234, bank: 53, code: 0x12, name: "", str: "Bank 53, Hex 0x12"},
+	} {
+		if got := tt.id.Bank(); got != tt.bank {
+			t.Errorf("%#04x.Bank() = %d, want %d", uint16(tt.id), got, tt.bank)
+		}
+		if got := tt.id.Code(); got != tt.code {
+			t.Errorf("%#04x.Code() = %#02x, want %#02x", uint16(tt.id), got, tt.code)
+		}
+		if got := tt.id.Name(); got != tt.name {
+			t.Errorf("%#04x.Name() = %q, want %q", uint16(tt.id), got, tt.name)
+		}
+		if got := tt.id.String(); got != tt.str {
+			t.Errorf("%#04x.String() = %q, want %q", uint16(tt.id), got, tt.str)
+		}
+	}
+}
+
+func TestLookupJEP106(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want JEP106ID
+		ok   bool
+	}{
+		{name: "Intel", want: 0x8980, ok: true},
+		{name: "sk hynix", want: 0xad80, ok: true},
+		{name: "Kingston", want: 0x9801, ok: true},
+		{name: "Elpida", want: 0xfe02, ok: true},
+		{name: "Nanya Technology", want: 0x0b83, ok: true},
+		{name: "Montage Technology Group", want: 0x3286, ok: true},
+		{name: "Micron", want: 0x2c80, ok: true},
+		{name: "Hynix Semiconductor Inc.", want: 0xad80, ok: true},
+		{name: "No Such Vendor"},
+	} {
+		got, ok := LookupJEP106(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LookupJEP106(%q) = %#04x, %v, want %#04x, %v", tt.name, uint16(got), ok, uint16(tt.want), tt.ok)
+		}
+	}
+}
+
+func TestJEP106RoundTrip(t *testing.T) {
+	for _, e := range jep106.entries {
+		if oddParity(e.id.Code()) != e.id.Code() {
+			t.Errorf("bank %d code %#02x (%s) has wrong parity", e.id.Bank(), e.id.Code(), e.name)
+		}
+		id, ok := LookupJEP106(e.name)
+		if !ok || id != e.id || id.Name() != e.name {
+			t.Errorf("LookupJEP106(%q) = %#04x, %v, want %#04x, true", e.name, uint16(id), ok, uint16(e.id))
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package registry resolves the vendor and manufacturer IDs found in SMBIOS
+// structures to names, and normalizes the manufacturer strings vendors put in
+// them.
+package registry
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// entry is the name of one ID in a registry.
+type entry[K comparable] struct {
+	id   K
+	name string
+}
+
+// index resolves the IDs of a registry to names and names back to IDs.
+type index[K comparable] struct {
+	entries []entry[K]
+
+	once   sync.Once
+	byID   map[K]string
+	byName map[string]K
+}
+
+func (x *index[K]) init() {
+	x.once.Do(func() {
+		x.byID = make(map[K]string, len(x.entries))
+		x.byName = make(map[string]K, 2*len(x.entries))
+		for _, e := range x.entries {
+			x.byID[e.id] = e.name
+			if _, ok := x.byName[strings.ToLower(e.name)]; !ok {
+				x.byName[strings.ToLower(e.name)] = e.id
+			}
+		}
+		// Normalized names never shadow registered names, and the first
+		// entry owns a normalized name shared by several.
+		for _, e := range x.entries {
+			n := strings.ToLower(normalizeName(e.name))
+			if _, ok := x.byName[n]; !ok && n != "" {
+				x.byName[n] = e.id
+			}
+		}
+	})
+}
+
+func (x *index[K]) name(id K) string {
+	x.init()
+	return x.byID[id]
+}
+
+func (x *index[K]) lookup(name string) (K, bool) {
+	x.init()
+	if id, ok := x.byName[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return id, true
+	}
+	if n := NormalizeVendor(name); n != "" {
+		if id, ok := x.byName[strings.ToLower(n)]; ok {
+			return id, true
+		}
+	}
+	var zero K
+	return zero, false
+}
+
+// NormalizeVendor returns the common name of a vendor given any of its
+// spellings, such as "Intel(R) Corporation", "GenuineIntel" or the JEP106 code
+// "8089", which are all normalized to "Intel". Placeholders like "Not
+// Specified" or "To Be Filled By O.E.M." yield an empty string. Unknown names
+// are returned with surrounding white space removed.
+func NormalizeVendor(name string) string {
+	if id, ok := parseJEP106(strings.TrimSpace(name)); ok {
+		if n := id.Name(); n != "" {
+			name = n
+		}
+	}
+	return normalizeName(name)
+}
+
+// normalizeName is NormalizeVendor without JEP106 codes, which lets the
+// registries normalize their own names.
+func normalizeName(name string) string {
+	key := vendorKey(name)
+	if isPlaceholder(key) {
+		return ""
+	}
+	if v, ok := vendorAliases[key]; ok {
+		return v
+	}
+	return strings.TrimSpace(name)
+}
+
+// corporateSuffixes are dropped from the end of vendor names before they are
+// compared.
+var corporateSuffixes = map[string]bool{
+	"inc": true, "inc.": true,
+	"corp": true, "corp.": true, "corporation": true,
+	"co": true, "co.": true,
+	"ltd": true, "ltd.": true, "limited": true,
+	"llc": true, "gmbh": true, "ag": true,
+}
+
+// vendorKey reduces a vendor name to lower case words without parenthesized
+// remarks such as "(R)" and without corporate suffixes.
+func vendorKey(name string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	words := strings.FieldsFunc(b.String(), func(r rune) bool { return r == ' ' || r == ',' })
+	for len(words) > 1 && corporateSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+func isPlaceholder(key string) bool {
+	switch key {
+	case "", "not specified", "not available", "to be filled by o.e.m.", "default string",
+		"undefined", "unknown", "none", "n/a", "oem", "o.e.m.", "system manufacturer", "manufacturer":
+		return true
+	}
+	// Some firmware fills unprogrammed fields with zeros.
+	return strings.Trim(key, "0") == ""
+}
+
+// parseJEP106 parses a JEP106 code given as 4 hex digits, the continuation
+// count followed by the ID, as some firmware does in manufacturer strings.
+func parseJEP106(s string) (JEP106ID, bool) {
+	if len(s) != 4 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	count, code := uint8(v>>8), uint8(v)
+	if oddParity(count) != count || oddParity(code) != code {
+		return 0, false
+	}
+	return JEP106ID(uint16(code)<<8 | uint16(count)), true
+}
+
+// vendorAliases maps vendor keys to common vendor names. Besides company names
+// it holds the CPUID vendor strings processors report.
+var vendorAliases = map[string]string{
+	"advanced micro devices":       "AMD",
+	"amd":                          "AMD",
+	"authenticamd":                 "AMD",
+	"apple":                        "Apple",
+	"apple computer":               "Apple",
+	"asus":                         "ASUS",
+	"asustek computer":             "ASUS",
+	"ati technologies":             "ATI",
+	"broadcom":                     "Broadcom",
+	"centaurhauls":                 "Centaur",
+	"cisco":                        "Cisco",
+	"cisco systems":                "Cisco",
+	"crucial":                      "Crucial",
+	"crucial technology":           "Crucial",
+	"cyrixinstead":                 "Cyrix",
+	"dell":                         "Dell",
+	"elpida":                       "Elpida",
+	"elpida memory":                "Elpida",
+	"genuineintel":                 "Intel",
+	"gigabyte":                     "Gigabyte",
+	"gigabyte technology":          "Gigabyte",
+	"google":                       "Google",
+	"hewlett-packard":              "HP",
+	"hewlett packard":              "HP",
+	"hp":                           "HP",
+	"hewlett packard enterprise":   "HPE",
+	"hpe":                          "HPE",
+	"huawei":                       "Huawei",
+	"huawei technologies":          "Huawei",
+	"hygon":                        "Hygon",
+	"hygongenuine":                 "Hygon",
+	"hynix":                        "SK Hynix",
+	"hynix semiconductor":          "SK Hynix",
+	"sk hynix":                     "SK Hynix",
+	"ibm":                          "IBM",
+	"idt":                          "IDT",
+	"integrated device technology": "IDT",
+	"infineon":                     "Infineon",
+	"infineon technologies":        "Infineon",
+	"intel":                        "Intel",
+	"kingston":                     "Kingston",
+	"kingston technology":          "Kingston",
+	"kioxia":                       "Kioxia",
+	"lenovo":                       "Lenovo",
+	"marvell":                      "Marvell",
+	"marvell technology group":     "Marvell",
+	"mellanox":                     "Mellanox",
+	"mellanox technologies":        "Mellanox",
+	"micro-star international":     "MSI",
+	"micron":                       "Micron",
+	"micron technology":            "Micron",
+	"microsoft":                    "Microsoft",
+	"montage":                      "Montage",
+	"montage technology":           "Montage",
+	"montage technology group":     "Montage",
+	"msi":                          "MSI",
+	"nanya":                        "Nanya",
+	"nanya technology":             "Nanya",
+	"nuvoton":                      "Nuvoton",
+	"nuvoton technology":           "Nuvoton",
+	"nvidia":                       "NVIDIA",
+	"qualcomm":                     "Qualcomm",
+	"qualcomm technologies":        "Qualcomm",
+	"realtek":                      "Realtek",
+	"realtek semiconductor":        "Realtek",
+	"samsung":                      "Samsung",
+	"samsung electronics":          "Samsung",
+	"shanghai":                     "Zhaoxin",
+	"stmicroelectronics":           "STMicroelectronics",
+	"super micro computer":         "Supermicro",
+	"supermicro":                   "Supermicro",
+	"texas instruments":            "Texas Instruments",
+	"toshiba":                      "Toshiba",
+	"vmware":                       "VMware",
+	"zhaoxin":                      "Zhaoxin",
+}
@@ -0,0 +1,42 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry
+
+import "testing"
+
+func TestNormalizeVendor(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want string
+	}{
+		{"GenuineIntel", "Intel"},
+		{"Intel(R) Corporation", "Intel"},
+		{"Intel Corporation", "Intel"},
+		{"AuthenticAMD", "AMD"},
+		{"Advanced Micro Devices, Inc.", "AMD"},
+		{"  Shanghai  ", "Zhaoxin"},
+		{"ASUSTeK COMPUTER INC.", "ASUS"},
+		{"Gigabyte Technology Co., Ltd.", "Gigabyte"},
+		{"Micron            ", "Micron"},
+		{"SK Hynix", "SK Hynix"},
+		{"Hynix Semiconductor Inc.", "SK Hynix"},
+		{"Infineon (Siemens)", "Infineon"},
+		{"80CE", "Samsung"},
+		{"859B", "Crucial"},
+		{"802C", "Micron"},
+		{"1315", "1315"},
+		{"Not Specified", ""},
+		{"To Be Filled By O.E.M.", ""},
+		{"Default string", ""},
+		{"Undefined         ", ""},
+		{"0000", ""},
+		{"  ", ""},
+		{"Thomas-Krenn.AG", "Thomas-Krenn.AG"},
+	} {
+		if got := NormalizeVendor(tt.name); got != tt.want {
+			t.Errorf("NormalizeVendor(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
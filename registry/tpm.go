@@ -0,0 +1,76 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry
+
+// TPMVendorID is a TPM vendor ID as defined in the TCG TPM Vendor ID Registry:
+// up to four ASCII characters, padded with NULs or spaces.
+type TPMVendorID [4]byte
+
+// LookupTPMVendor returns the ID of the TPM vendor with the given name, which
+// is either its registered name or any spelling NormalizeVendor knows. The
+// comparison ignores case.
+func LookupTPMVendor(name string) (TPMVendorID, bool) {
+	return tpmVendors.lookup(name)
+}
+
+// Name returns the registered name of the vendor, or an empty string if the ID
+// is not known.
+func (id TPMVendorID) Name() string {
+	return tpmVendors.name(id.canonical())
+}
+
+func (id TPMVendorID) String() string {
+	s := ""
+	for _, c := range id.canonical() {
+		if c == 0 {
+			break
+		}
+		s += string(c)
+	}
+	return s
+}
+
+// canonical returns the ID as registered.
+func (id TPMVendorID) canonical() TPMVendorID {
+	// DSP0134 specifies Vendor ID field as 4 BYTEs, not a DWORD, and gives an example value.
+	// But Infineon ignores it and puts their VID in LE byte order so it ends up backwards.
+	if id == (TPMVendorID{0, 'X', 'F', 'I'}) {
+		return TPMVendorID{'I', 'F', 'X', 0}
+	}
+	return id
+}
+
+func tpmVendorID(s string) TPMVendorID {
+	var id TPMVendorID
+	copy(id[:], s)
+	return id
+}
+
+var tpmVendors = &index[TPMVendorID]{entries: []entry[TPMVendorID]{
+	{tpmVendorID("AMD"), "AMD"},
+	{tpmVendorID("ATML"), "Atmel"},
+	{tpmVendorID("BRCM"), "Broadcom"},
+	{tpmVendorID("CSCO"), "Cisco"},
+	{tpmVendorID("FLYS"), "Flyslice Technologies"},
+	{tpmVendorID("ROCC"), "Fuzhou Rockchip"},
+	{tpmVendorID("GOOG"), "Google"},
+	{tpmVendorID("HPE"), "HPE"},
+	{tpmVendorID("HISI"), "Huawei"},
+	{tpmVendorID("IBM"), "IBM"},
+	{tpmVendorID("IFX"), "Infineon"},
+	{tpmVendorID("INTC"), "Intel"},
+	{tpmVendorID("LEN"), "Lenovo"},
+	{tpmVendorID("MSFT"), "Microsoft"},
+	{tpmVendorID("NSM "), "National Semiconductor"},
+	{tpmVendorID("NTZ"), "Nationz"},
+	{tpmVendorID("NTC"), "Nuvoton Technology"},
+	{tpmVendorID("QCOM"), "Qualcomm"},
+	{tpmVendorID("SMSC"), "SMSC"},
+	{tpmVendorID("STM "), "STMicroelectronics"},
+	{tpmVendorID("SMSN"), "Samsung"},
+	{tpmVendorID("SNS"), "Sinosun"},
+	{tpmVendorID("TXN"), "Texas Instruments"},
+	{tpmVendorID("WEC"), "Winbond"},
+}}
@@ -0,0 +1,48 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package registry
+
+import "testing"
+
+func TestTPMVendorID(t *testing.T) {
+	for _, tt := range []struct {
+		id   TPMVendorID
+		name string
+		str  string
+	}{
+		{id: TPMVendorID{'I', 'N', 'T', 'C'}, name: "Intel", str: "INTC"},
+		{id: TPMVendorID{'I', 'F', 'X', 0}, name: "Infineon", str: "IFX"},
+		{id: TPMVendorID{0, 'X', 'F', 'I'}, name: "Infineon", str: "IFX"},
+		{id: TPMVendorID{'S', 'T', 'M', ' '}, name: "STMicroelectronics", str: "STM "},
+		{id: TPMVendorID{'A', 'B', 'C', 'D'}, name: "", str: "ABCD"},
+		{id: TPMVendorID{}, name: "", str: ""},
+	} {
+		if got := tt.id.Name(); got != tt.name {
+			t.Errorf("%q.Name() = %q, want %q", tt.id[:], got, tt.name)
+		}
+		if got := tt.id.String(); got != tt.str {
+			t.Errorf("%q.String() = %q, want %q", tt.id[:], got, tt.str)
+		}
+	}
+}
+
+func TestLookupTPMVendor(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		want TPMVendorID
+		ok   bool
+	}{
+		{name: "Infineon", want: TPMVendorID{'I', 'F', 'X', 0}, ok: true},
+		{name: "Infineon Technologies AG", want: TPMVendorID{'I', 'F', 'X', 0}, ok: true},
+		{name: "nuvoton", want: TPMVendorID{'N', 'T', 'C', 0}, ok: true},
+		{name: "Intel(R) Corporation", want: TPMVendorID{'I', 'N', 'T', 'C'}, ok: true},
+		{name: "No Such Vendor"},
+	} {
+		got, ok := LookupTPMVendor(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LookupTPMVendor(%q) = %q, %v, want %q, %v", tt.name, got[:], ok, tt.want[:], tt.ok)
+		}
+	}
+}
@@ -7,26 +7,66 @@ package smbios
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 )
 
 // Errors for entry points.
 var (
-	ErrAnchorNotFound = errors.New("SMBIOS anchor _SM_ or _SM3_ not found")
+	ErrAnchorNotFound = errors.New("SMBIOS anchor _SM_, _SM3_ or _DMI_ not found")
 )
 
-// getMemBase searches _SM_ or _SM3_ tag in the given memory range.
+// legacyDMIHeaderSize is the size of the DMI 2.0 entry point, which has no
+// SMBIOS entry point around it.
+const legacyDMIHeaderSize = 0x0f
+
+// validLegacyDMI returns whether data starts with a DMI 2.0 entry point with
+// a valid checksum.
+func validLegacyDMI(data []byte) bool {
+	return len(data) >= legacyDMIHeaderSize &&
+		bytes.Equal(data[:5], anchorDMI) &&
+		calcChecksum(data[:legacyDMIHeaderSize], 5) == data[5]
+}
+
+// getMemBase searches the entry point in the given memory range, on 16-byte
+// paragraph boundaries as specified in DSP0134 5.2. Candidates must have a
+// valid checksum. A SMBIOS 3 (_SM3_) entry point is preferred over a SMBIOS 2
+// (_SM_) one, which is preferred over a bare DMI 2.0 (_DMI_) one.
 func getMemBase(r io.ReaderAt, start, end int64) (addr int64, size int64, err error) {
-	b := make([]byte, 5)
-	for base := start; base < end-5; base++ {
-		if _, err := io.ReadFull(io.NewSectionReader(r, base, 5), b); err != nil {
-			return 0, 0, err
-		}
-		if bytes.Equal(b[:4], anchor32) {
-			return base, smbios2HeaderSize, nil
+	if end <= start {
+		return 0, 0, ErrAnchorNotFound
+	}
+	seg := make([]byte, end-start)
+	if _, err := r.ReadAt(seg, start); err != nil {
+		return 0, 0, fmt.Errorf("error reading memory at 0x%x: %w", start, convertUnexpectedEOF(err))
+	}
+
+	var found [3]int64
+	for i := range found {
+		found[i] = -1
+	}
+	for off := (16 - start%16) % 16; off < int64(len(seg)); off += 16 {
+		data := seg[off:]
+		switch {
+		case bytes.HasPrefix(data, anchor64):
+			var e Entry64
+			if found[0] < 0 && len(data) >= smbios3HeaderSize && e.UnmarshalBinary(data[:smbios3HeaderSize]) == nil {
+				found[0] = off
+			}
+		case bytes.HasPrefix(data, anchor32):
+			var e Entry32
+			if found[1] < 0 && len(data) >= smbios2HeaderSize && e.UnmarshalBinary(data[:smbios2HeaderSize]) == nil {
+				found[1] = off
+			}
+		case bytes.HasPrefix(data, anchorDMI):
+			if found[2] < 0 && validLegacyDMI(data) {
+				found[2] = off
+			}
 		}
-		if bytes.Equal(b, anchor64) {
-			return base, smbios3HeaderSize, nil
+	}
+	for i, size := range []int64{smbios3HeaderSize, smbios2HeaderSize, legacyDMIHeaderSize} {
+		if found[i] >= 0 {
+			return start + found[i], size, nil
 		}
 	}
 	return 0, 0, ErrAnchorNotFound
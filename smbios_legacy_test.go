@@ -14,8 +14,45 @@ import (
 	"github.com/hugelgupf/vmtest/guest"
 )
 
+// legacyDMI returns a DMI 2.0 entry point.
+func legacyDMI() []byte {
+	b := []byte{'_', 'D', 'M', 'I', '_', 0, 0x40, 0x01, 0x00, 0x00, 0x0e, 0x00, 0x10, 0x00, 0x20}
+	b[5] = calcChecksum(b, 5)
+	return b
+}
+
 func TestLegacy(t *testing.T) {
+	e32, _ := testMemTables(t)
+	entry32, err := e32.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry64, err := (&Entry64{
+		Anchor:       [5]uint8{'_', 'S', 'M', '3', '_'},
+		Length:       smbios3HeaderSize,
+		MajorVersion: 3,
+	}).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Both the SMBIOS and the embedded DMI checksum are wrong.
+	badEntry32 := append([]byte{}, entry32...)
+	badEntry32[0x16]++
+	// Only the SMBIOS checksum is wrong.
+	badSMEntry32 := append([]byte{}, entry32...)
+	badSMEntry32[4]++
+
+	// mem returns a 0x100 byte memory image with data at the given offsets.
+	mem := func(at ...interface{}) []byte {
+		b := make([]byte, 0x100)
+		for i := 0; i < len(at); i += 2 {
+			copy(b[at[i].(int):], at[i+1].([]byte))
+		}
+		return b
+	}
+
 	for _, tt := range []struct {
+		name string
 		// in
 		b     []byte
 		start int64
@@ -26,33 +63,75 @@ func TestLegacy(t *testing.T) {
 		err  error
 	}{
 		{
+			name:  "no anchor",
 			b:     bytes.Repeat([]byte{0}, 100),
 			start: 10,
 			end:   100,
 			err:   ErrAnchorNotFound,
 		},
 		{
-			b:     []byte{0, '_', 'M', 'S', '_', 0, 0, '_', 'S', 'M', '_', 0, 0, 0, 0, 0},
-			start: 1,
-			end:   15,
-			addr:  7,
+			name:  "32-bit",
+			b:     mem(0x10, badEntry32, 0x40, entry32),
+			start: 0,
+			end:   0x100,
+			addr:  0x40,
 			size:  smbios2HeaderSize,
 		},
 		{
-			b:     []byte{0, '_', 'M', 'S', '_', 0, 0, '_', 'S', 'M', '3', '_', 0, 0, 0, 0, 0},
-			start: 1,
-			end:   15,
-			addr:  7,
+			name:  "64-bit preferred",
+			b:     mem(0x10, entry32, 0x80, entry64),
+			start: 0,
+			end:   0x100,
+			addr:  0x80,
 			size:  smbios3HeaderSize,
 		},
 		{
+			name:  "unaligned",
+			b:     mem(0x18, entry32, 0x89, entry64),
+			start: 0,
+			end:   0x100,
+			err:   ErrAnchorNotFound,
+		},
+		{
+			name:  "unaligned start",
+			b:     mem(0x20, entry32),
+			start: 0x18,
+			end:   0x100,
+			addr:  0x20,
+			size:  smbios2HeaderSize,
+		},
+		{
+			name:  "bad checksum",
+			b:     mem(0x20, badEntry32),
+			start: 0,
+			end:   0x100,
+			err:   ErrAnchorNotFound,
+		},
+		{
+			name:  "embedded DMI",
+			b:     mem(0x20, badSMEntry32),
+			start: 0,
+			end:   0x100,
+			addr:  0x30,
+			size:  legacyDMIHeaderSize,
+		},
+		{
+			name:  "legacy DMI",
+			b:     mem(0x30, legacyDMI()),
+			start: 0,
+			end:   0x100,
+			addr:  0x30,
+			size:  legacyDMIHeaderSize,
+		},
+		{
+			name:  "short read",
 			b:     []byte{0, '_', 'M', 'S', '_', 0, 0, '_', 'S'},
 			start: 1,
 			end:   15,
 			err:   io.ErrUnexpectedEOF,
 		},
 	} {
-		t.Run("", func(t *testing.T) {
+		t.Run(tt.name, func(t *testing.T) {
 			addr, size, err := getMemBase(bytes.NewReader(tt.b), tt.start, tt.end)
 			if !errors.Is(err, tt.err) {
 				t.Errorf("getMemBase = %v, want %v", err, tt.err)
@@ -15,6 +15,61 @@ import (
 
 var systabPath = "/sys/firmware/efi/systab"
 
+// EFISource reads from /dev/mem at the entry point address given by the EFI
+// System Table, as exported by Linux in /sys/firmware/efi/systab.
+//
+// Empty SystabPath and MemPath select the default paths.
+type EFISource struct {
+	SystabPath string
+	MemPath    string
+}
+
+var _ Source = EFISource{}
+
+func (s EFISource) paths() (string, string) {
+	systab, mem := s.SystabPath, s.MemPath
+	if systab == "" {
+		systab = systabPath
+	}
+	if mem == "" {
+		mem = "/dev/mem"
+	}
+	return systab, mem
+}
+
+// Read implements Source.Read.
+func (s EFISource) Read() ([]byte, []byte, error) {
+	systab, mem := s.paths()
+	base, _, err := entryBaseFromEFI(systab)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(mem)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return ReadMemory(f, base)
+}
+
+// String implements Source.String.
+func (s EFISource) String() string {
+	_, mem := s.paths()
+	return "EFI system table and " + mem
+}
+
+// DefaultSources returns the sources to read SMBIOS data from on Linux, in
+// order of preference: sysfs, then /dev/mem via the EFI System Table, the
+// coreboot table, or the legacy F0000 segment scan.
+func DefaultSources() []Source {
+	return []Source{
+		SysfsSource{Path: DefaultSysfsPath},
+		EFISource{},
+		CorebootSource{MemPath: "/dev/mem"},
+		MemoryImageSource{Path: "/dev/mem"},
+	}
+}
+
 // EntryBase returns SMBIOS base pointer, which points to the entry point address.
 func EntryBase() (int64, int64, error) {
 	base, size, err := EntryBaseFromEFI()
@@ -40,6 +95,9 @@ func EntryBaseFromLegacy() (int64, int64, error) {
 }
 
 // EntryBaseFromEFI finds the SMBIOS entry point address in the EFI System Table.
+//
+// It relies on /sys/firmware/efi/systab, which newer kernels no longer
+// populate. See EntryBaseFromEFISystemTable to walk the table in memory.
 func EntryBaseFromEFI() (base int64, size int64, err error) {
 	return entryBaseFromEFI(systabPath)
 }
@@ -0,0 +1,227 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSysfsPath is where Linux exports the SMBIOS entry point and table.
+const DefaultSysfsPath = "/sys/firmware/dmi/tables"
+
+// ErrNoSource is returned by ReadFirst when it is given no sources.
+var ErrNoSource = errors.New("no SMBIOS data source")
+
+// Source provides raw SMBIOS entry point and structure table data.
+type Source interface {
+	// Read returns the raw entry point and structure table data.
+	Read() (entryData, tableData []byte, err error)
+
+	// String describes where the data is read from.
+	String() string
+}
+
+// SysfsSource reads the smbios_entry_point and DMI files of a directory,
+// usually DefaultSysfsPath.
+type SysfsSource struct {
+	Path string
+}
+
+var _ Source = SysfsSource{}
+
+// Read implements Source.Read.
+func (s SysfsSource) Read() ([]byte, []byte, error) {
+	entry, err := os.ReadFile(filepath.Join(s.Path, "smbios_entry_point"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading SMBIOS entry data: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(s.Path, "DMI"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading DMI data: %w", err)
+	}
+	return entry, data, nil
+}
+
+// String implements Source.String.
+func (s SysfsSource) String() string {
+	if s.Path == DefaultSysfsPath {
+		return "sysfs"
+	}
+	return "sysfs at " + s.Path
+}
+
+// DumpFileSource reads a file written by dmidecode(8) --dump-bin. Such a file
+// starts with the entry point, and the table is at the file offset given as
+// table address in the entry point.
+type DumpFileSource struct {
+	Path string
+}
+
+var _ Source = DumpFileSource{}
+
+// Read implements Source.Read.
+func (s DumpFileSource) Read() ([]byte, []byte, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return ReadMemory(f, 0)
+}
+
+// String implements Source.String.
+func (s DumpFileSource) String() string {
+	return "file " + s.Path
+}
+
+// MemoryImageSource reads from an image of physical memory, such as /dev/mem.
+// The entry point is at Base. If Base is 0, the F0000 segment is scanned for
+// the entry point, as on legacy BIOS systems.
+type MemoryImageSource struct {
+	Path string
+	Base int64
+}
+
+var _ Source = MemoryImageSource{}
+
+// Read implements Source.Read.
+func (s MemoryImageSource) Read() ([]byte, []byte, error) {
+	f, err := os.Open(s.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	base := s.Base
+	if base == 0 {
+		if base, _, err = getMemBase(f, 0xf0000, 0x100000); err != nil {
+			return nil, nil, err
+		}
+	}
+	return ReadMemory(f, base)
+}
+
+// String implements Source.String.
+func (s MemoryImageSource) String() string {
+	return s.Path
+}
+
+// EFISystemTableSource reads from an image of physical memory, such as
+// /dev/mem, at the entry point address found in the configuration table of
+// the EFI System Table at address SystemTable. The address is usually known
+// from the boot parameters.
+type EFISystemTableSource struct {
+	MemPath     string
+	SystemTable int64
+}
+
+var _ Source = EFISystemTableSource{}
+
+// Read implements Source.Read.
+func (s EFISystemTableSource) Read() ([]byte, []byte, error) {
+	f, err := os.Open(s.MemPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	base, _, err := EntryBaseFromEFISystemTable(f, s.SystemTable)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadMemory(f, base)
+}
+
+// String implements Source.String.
+func (s EFISystemTableSource) String() string {
+	return fmt.Sprintf("EFI System Table at 0x%x in %s", s.SystemTable, s.MemPath)
+}
+
+// CorebootSource reads from an image of physical memory, such as /dev/mem,
+// at the entry point found in the SMBIOS CBMEM region listed in the coreboot
+// table.
+type CorebootSource struct {
+	MemPath string
+}
+
+var _ Source = CorebootSource{}
+
+// Read implements Source.Read.
+func (s CorebootSource) Read() ([]byte, []byte, error) {
+	f, err := os.Open(s.MemPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	base, _, err := EntryBaseFromCoreboot(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadMemory(f, base)
+}
+
+// String implements Source.String.
+func (s CorebootSource) String() string {
+	return "coreboot tables in " + s.MemPath
+}
+
+// ImageSource reads from a raw firmware image file, such as a .rom or .fd
+// file. See ReadImage.
+type ImageSource struct {
+	Path string
+}
+
+var _ Source = ImageSource{}
+
+// Read implements Source.Read.
+func (s ImageSource) Read() ([]byte, []byte, error) {
+	img, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ReadImage(img)
+}
+
+// String implements Source.String.
+func (s ImageSource) String() string {
+	return "firmware image " + s.Path
+}
+
+// BytesSource returns entry point and table data that has already been read.
+type BytesSource struct {
+	Entry []byte
+	Table []byte
+}
+
+var _ Source = BytesSource{}
+
+// Read implements Source.Read.
+func (s BytesSource) Read() ([]byte, []byte, error) {
+	return s.Entry, s.Table, nil
+}
+
+// String implements Source.String.
+func (s BytesSource) String() string {
+	return "memory"
+}
+
+// ReadFirst reads from the first of srcs that can be read, and returns the
+// data along with the source it was read from. If no source can be read, the
+// errors of all sources are returned.
+func ReadFirst(srcs ...Source) (entryData, tableData []byte, src Source, err error) {
+	if len(srcs) == 0 {
+		return nil, nil, nil, ErrNoSource
+	}
+	var errs []error
+	for _, src := range srcs {
+		entryData, tableData, err := src.Read()
+		if err == nil {
+			return entryData, tableData, src, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", src, err))
+	}
+	return nil, nil, nil, errors.Join(errs...)
+}
@@ -0,0 +1,87 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSources(t *testing.T) {
+	data, err := os.ReadFile(testbinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEntry, wantTable := data[:smbios2HeaderSize], data[32:]
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "smbios_entry_point"), wantEntry, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "DMI"), wantTable, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	e, table := testMemTables(t)
+	e.StructTableAddr = 0x1000
+	memEntry, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	memFile := filepath.Join(dir, "mem")
+	if err := os.WriteFile(memFile, memImage(0x2000, 0x10, memEntry, 0x1000, table), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		src       Source
+		name      string
+		wantEntry []byte
+	}{
+		{SysfsSource{Path: dir}, "sysfs at " + dir, wantEntry},
+		{DumpFileSource{Path: testbinary}, "file " + testbinary, wantEntry},
+		{MemoryImageSource{Path: memFile, Base: 0x10}, memFile, memEntry},
+		{BytesSource{Entry: wantEntry, Table: wantTable}, "memory", wantEntry},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.src.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+			entry, table, err := tt.src.Read()
+			if err != nil {
+				t.Fatalf("Read() = %v", err)
+			}
+			if !bytes.Equal(entry, tt.wantEntry) {
+				t.Errorf("Read() entry = %x, want %x", entry, tt.wantEntry)
+			}
+			if !bytes.Equal(table, wantTable) {
+				t.Errorf("Read() table has %d bytes, want %d", len(table), len(wantTable))
+			}
+		})
+	}
+}
+
+func TestReadFirst(t *testing.T) {
+	missing := SysfsSource{Path: "testdata/NOT_FOUND"}
+	bs := BytesSource{Entry: []byte{1}, Table: []byte{2}}
+
+	entry, table, src, err := ReadFirst(missing, DumpFileSource{Path: "testdata/NOT_FOUND"}, bs)
+	if err != nil {
+		t.Fatalf("ReadFirst() = %v", err)
+	}
+	if _, ok := src.(BytesSource); !ok || !bytes.Equal(entry, bs.Entry) || !bytes.Equal(table, bs.Table) {
+		t.Errorf("ReadFirst() = %x, %x, %v, want %x, %x, %v", entry, table, src, bs.Entry, bs.Table, bs)
+	}
+
+	if _, _, _, err := ReadFirst(missing); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadFirst() = %v, want %v", err, os.ErrNotExist)
+	}
+	if _, _, _, err := ReadFirst(); !errors.Is(err, ErrNoSource) {
+		t.Errorf("ReadFirst() = %v, want %v", err, ErrNoSource)
+	}
+}
@@ -21,6 +21,10 @@ type Table struct {
 
 	Data    []byte   `smbios:"-"` // Structured part of the table.
 	Strings []string `smbios:"-"` // Strings section.
+
+	// Version is the SMBIOS version advertised by the entry point of the
+	// table, if known. It decides which fields the table may contain.
+	Version Version `smbios:"-"`
 }
 
 // Table parsing errors.
@@ -131,6 +135,26 @@ func (t *Table) String() string {
 	return strings.Join(lines, "\n")
 }
 
+// MarshalBinary encodes the table as it appears in the structure table: the
+// header, the structured part and the strings section.
+func (t *Table) MarshalBinary() ([]byte, error) {
+	if int(t.Length) != t.Len() {
+		return nil, fmt.Errorf("length mismatch: header says %d bytes, table has %d", t.Length, t.Len())
+	}
+	data := append(t.Header.ToBytes(), t.Data...)
+	if len(t.Strings) == 0 {
+		return append(data, 0, 0), nil
+	}
+	for i, s := range t.Strings {
+		if s == "" || strings.IndexByte(s, 0) >= 0 {
+			return nil, fmt.Errorf("string %d cannot be encoded: %q", i+1, s)
+		}
+		data = append(data, s...)
+		data = append(data, 0)
+	}
+	return append(data, 0), nil
+}
+
 func readFormatted(r io.Reader, l int) ([]byte, error) {
 	if l < 0 {
 		return nil, io.ErrUnexpectedEOF
@@ -206,6 +230,19 @@ func ParseTables(r io.Reader) (Tables, error) {
 	// Unreachable.
 }
 
+// MarshalBinary encodes the tables as a structure table.
+func (t Tables) MarshalBinary() ([]byte, error) {
+	var data []byte
+	for _, u := range t {
+		b, err := u.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("table handle 0x%04x: %w", u.Handle, err)
+		}
+		data = append(data, b...)
+	}
+	return data, nil
+}
+
 // TablesByType returns tables of the specified type.
 //
 // TablesByType is nil-safe.
@@ -237,6 +274,19 @@ func (t Tables) TableByType(tt TableType) *Table {
 	return nil
 }
 
+// TableByHandle returns the first table with the specified handle. It scans
+// the tables in order, which takes time linear in their number.
+//
+// TableByHandle is nil-safe.
+func (t Tables) TableByHandle(handle uint16) *Table {
+	for _, u := range t {
+		if u.Handle == handle {
+			return u
+		}
+	}
+	return nil
+}
+
 // ParseTable parses a table from byte stream.
 func ParseTable(r io.Reader) (*Table, error) {
 	br := bufio.NewReader(r)
@@ -629,3 +629,23 @@ OEM-specific Type
 		t.Errorf("Wrong length: Got %d want %d", got, 14)
 	}
 }
+
+func TestByHandle(t *testing.T) {
+	tt := Tables{
+		&Table{Header: Header{Type: 1, Length: 4, Handle: 1}},
+		&Table{Header: Header{Type: 2, Length: 4, Handle: 2}},
+		&Table{Header: Header{Type: 3, Length: 4, Handle: 2}},
+	}
+	if got := tt.TableByHandle(2); got != tt[1] {
+		t.Errorf("TableByHandle(2) = %v, want %v", got, tt[1])
+	}
+	if got := tt.TableByHandle(3); got != nil {
+		t.Errorf("TableByHandle(3) = %v, want %v", got, nil)
+	}
+
+	// Test nil safety.
+	tt = nil
+	if got := tt.TableByHandle(1); got != nil {
+		t.Errorf("TableByHandle(1) = %v, want %v", got, nil)
+	}
+}
@@ -10,33 +10,37 @@ type TableType uint8
 
 // Supported table types.
 const (
-	TableTypeBIOSInfo       TableType = 0
-	TableTypeSystemInfo     TableType = 1
-	TableTypeBaseboardInfo  TableType = 2
-	TableTypeChassisInfo    TableType = 3
-	TableTypeProcessorInfo  TableType = 4
-	TableTypeCacheInfo      TableType = 7
-	TableTypeSystemSlots    TableType = 9
-	TableTypeMemoryDevice   TableType = 17
-	TableTypeIPMIDeviceInfo TableType = 38
-	TableTypeTPMDevice      TableType = 43
-	TableTypeInactive       TableType = 126
-	TableTypeEndOfTable     TableType = 127
+	TableTypeBIOSInfo                 TableType = 0
+	TableTypeSystemInfo               TableType = 1
+	TableTypeBaseboardInfo            TableType = 2
+	TableTypeChassisInfo              TableType = 3
+	TableTypeProcessorInfo            TableType = 4
+	TableTypeCacheInfo                TableType = 7
+	TableTypeSystemSlots              TableType = 9
+	TableTypePhysicalMemoryArray      TableType = 16
+	TableTypeMemoryDevice             TableType = 17
+	TableTypeMemoryArrayMappedAddress TableType = 19
+	TableTypeIPMIDeviceInfo           TableType = 38
+	TableTypeTPMDevice                TableType = 43
+	TableTypeInactive                 TableType = 126
+	TableTypeEndOfTable               TableType = 127
 )
 
 var tableTypeToString = map[TableType]string{
-	TableTypeBIOSInfo:       "BIOS Information",
-	TableTypeSystemInfo:     "System Information",
-	TableTypeBaseboardInfo:  "Base Board Information",
-	TableTypeChassisInfo:    "Chassis Information",
-	TableTypeProcessorInfo:  "Processor Information",
-	TableTypeCacheInfo:      "Cache Information",
-	TableTypeSystemSlots:    "System Slots",
-	TableTypeMemoryDevice:   "Memory Device",
-	TableTypeIPMIDeviceInfo: "IPMI Device Information",
-	TableTypeTPMDevice:      "TPM Device",
-	TableTypeInactive:       "Inactive",
-	TableTypeEndOfTable:     "End Of Table",
+	TableTypeBIOSInfo:                 "BIOS Information",
+	TableTypeSystemInfo:               "System Information",
+	TableTypeBaseboardInfo:            "Base Board Information",
+	TableTypeChassisInfo:              "Chassis Information",
+	TableTypeProcessorInfo:            "Processor Information",
+	TableTypeCacheInfo:                "Cache Information",
+	TableTypeSystemSlots:              "System Slots",
+	TableTypePhysicalMemoryArray:      "Physical Memory Array",
+	TableTypeMemoryDevice:             "Memory Device",
+	TableTypeMemoryArrayMappedAddress: "Memory Array Mapped Address",
+	TableTypeIPMIDeviceInfo:           "IPMI Device Information",
+	TableTypeTPMDevice:                "TPM Device",
+	TableTypeInactive:                 "Inactive",
+	TableTypeEndOfTable:               "End Of Table",
 }
 
 func (t TableType) String() string {
@@ -40,10 +40,18 @@ func TestTableTypeString(t *testing.T) {
 			tableType: TableTypeSystemSlots,
 			want:      "System Slots",
 		},
+		{
+			tableType: TableTypePhysicalMemoryArray,
+			want:      "Physical Memory Array",
+		},
 		{
 			tableType: TableTypeMemoryDevice,
 			want:      "Memory Device",
 		},
+		{
+			tableType: TableTypeMemoryArrayMappedAddress,
+			want:      "Memory Array Mapped Address",
+		},
 		{
 			tableType: TableTypeIPMIDeviceInfo,
 			want:      "IPMI Device Information",
@@ -0,0 +1,77 @@
+// Copyright 2016-2019 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is an SMBIOS specification version. The zero Version is unknown.
+type Version struct {
+	Major uint8
+	Minor uint8
+}
+
+// EntryVersion returns the SMBIOS version advertised by the entry point.
+func EntryVersion(e EntryPoint) Version {
+	major, minor, _ := e.Version()
+	return Version{Major: uint8(major), Minor: uint8(minor)}
+}
+
+// ParseVersion parses a version of the form "major.minor", such as "2.6".
+func ParseVersion(s string) (Version, error) {
+	major, minor, ok := strings.Cut(s, ".")
+	if !ok {
+		return Version{}, fmt.Errorf("invalid SMBIOS version %q", s)
+	}
+	ma, err := strconv.ParseUint(major, 10, 8)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid SMBIOS version %q", s)
+	}
+	mi, err := strconv.ParseUint(minor, 10, 8)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid SMBIOS version %q", s)
+	}
+	return Version{Major: uint8(ma), Minor: uint8(mi)}, nil
+}
+
+// IsZero reports whether the version is unknown.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+// Supports reports whether tables of version v may contain fields that were
+// introduced in version u. An unknown version supports all fields, leaving it
+// to the table length to tell which fields are present.
+func (v Version) Supports(u Version) bool {
+	if v.IsZero() {
+		return true
+	}
+	if v.Major != u.Major {
+		return v.Major > u.Major
+	}
+	return v.Minor >= u.Minor
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Version) UnmarshalText(text []byte) error {
+	u, err := ParseVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = u
+	return nil
+}
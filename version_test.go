@@ -0,0 +1,64 @@
+// Copyright 2016-2021 the u-root Authors. All rights reserved
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package smbios
+
+import (
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want Version
+		err  bool
+	}{
+		{s: "2.6", want: Version{2, 6}},
+		{s: "3.10", want: Version{3, 10}},
+		{s: "3", err: true},
+		{s: "3.x", err: true},
+		{s: "256.0", err: true},
+	} {
+		got, err := ParseVersion(tt.s)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseVersion(%q) = %v, want error %v", tt.s, err, tt.err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseVersion(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestVersionSupports(t *testing.T) {
+	for _, tt := range []struct {
+		v, u Version
+		want bool
+	}{
+		{Version{}, Version{3, 7}, true},
+		{Version{2, 6}, Version{2, 6}, true},
+		{Version{2, 8}, Version{2, 6}, true},
+		{Version{3, 0}, Version{2, 8}, true},
+		{Version{2, 5}, Version{2, 6}, false},
+		{Version{2, 8}, Version{3, 0}, false},
+	} {
+		if got := tt.v.Supports(tt.u); got != tt.want {
+			t.Errorf("%v.Supports(%v) = %v, want %v", tt.v, tt.u, got, tt.want)
+		}
+	}
+}
+
+func TestEntryVersion(t *testing.T) {
+	for _, tt := range []struct {
+		e    EntryPoint
+		want Version
+	}{
+		{&Entry32{MajorVersion: 2, MinorVersion: 8}, Version{2, 8}},
+		{&Entry64{MajorVersion: 3, MinorVersion: 2, DocRev: 1}, Version{3, 2}},
+		{&EntryDMI{BCDRevision: 0x20}, Version{2, 0}},
+	} {
+		if got := EntryVersion(tt.e); got != tt.want {
+			t.Errorf("EntryVersion(%v) = %v, want %v", tt.e, got, tt.want)
+		}
+	}
+}